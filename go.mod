@@ -1,11 +1,8 @@
 module github.com/StevenCyb/goeval
 
-go 1.22.2
+go 1.23
 
-require (
-	github.com/StevenCyb/gotokenizer v0.1.2
-	github.com/stretchr/testify v1.9.0
-)
+require github.com/stretchr/testify v1.9.0
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -0,0 +1,76 @@
+package errs
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go run ./internal/catalogdoc ../../docs/errors.md
+
+// CatalogEntry documents an error code.
+type CatalogEntry struct {
+	// ID is the stable identifier like "E0001", IDs are never reused.
+	ID          string
+	Code        Code
+	Description string
+}
+
+// catalog lists all codes, new codes are appended with the next ID.
+var catalog = []CatalogEntry{
+	{ID: "E0000", Code: CodeUnknown, Description: "The error is not one of the errors of goeval."},
+	{ID: "E0001", Code: CodeUnexpectedToken, Description: "A token appears where another one was expected, e.g. `(1 + )`."},
+	{ID: "E0002", Code: CodeDivisionByZero, Description: "The divisor of `/`, `//` or `%` is zero."},
+	{ID: "E0003", Code: CodeUnexpectedInputEnd, Description: "The expression ends where more was expected, e.g. `1 +`."},
+	{ID: "E0004", Code: CodeUnexpectedCharacter, Description: "A character that starts no token, e.g. `#`."},
+	{ID: "E0005", Code: CodeEmptyExpression, Description: "The expression is empty or only whitespace."},
+	{ID: "E0006", Code: CodeMalformedNumber, Description: "A number literal is malformed, e.g. `1__0` or `0b102`."},
+	{ID: "E0007", Code: CodeNumberOutOfRange, Description: "A number literal exceeds the float64 range, e.g. `1e400`."},
+	{ID: "E0008", Code: CodeInvalidEscape, Description: "A text contains an unknown or incomplete escape sequence, e.g. `'\\q'`."},
+	{ID: "E0009", Code: CodeUnknownIdentifier, Description: "An identifier is neither a keyword nor a variable of the environment."},
+	{ID: "E0010", Code: CodeIntegerOverflow, Description: "An integer operation overflows int64 and promotion is disabled, or the promoted value exceeds `expr.WithMaxIntegerBits`."},
+	{ID: "E0011", Code: CodeNotInteger, Description: "A bitwise operation got an operand that is not a whole number."},
+	{ID: "E0012", Code: CodeNegativeShift, Description: "The shift amount of `<<` or `>>` is negative."},
+	{ID: "E0013", Code: CodeUnknownFunction, Description: "A called function is not registered by `expr.WithFunction`."},
+	{ID: "E0014", Code: CodePanic, Description: "A function or resolver panicked, the stack trace is available by `errs.PanicError`."},
+	{ID: "E0015", Code: CodeCanceled, Description: "The context of `EvalContext` was canceled during evaluation."},
+	{ID: "E0016", Code: CodeDeadlineExceeded, Description: "The deadline of the context of `EvalContext` passed during evaluation."},
+	{ID: "E0017", Code: CodeSourceTooLong, Description: "The expression is longer than `expr.WithMaxSourceLength`."},
+	{ID: "E0018", Code: CodeMaxDepth, Description: "The expression is nested deeper than `expr.WithMaxDepth`."},
+	{ID: "E0019", Code: CodeMaxSteps, Description: "The evaluation takes more steps than `expr.WithMaxSteps`."},
+	{ID: "E0020", Code: CodeStringTooLong, Description: "A text is longer than `expr.WithMaxStringLength`."},
+	{ID: "E0021", Code: CodeMaxCost, Description: "The estimated cost of the expression exceeds `expr.WithMaxCost`."},
+	{ID: "E0022", Code: CodeColumnLength, Description: "The columns of `Program.EvalColumns` have different lengths."},
+}
+
+// Catalog returns all error codes ordered by ID.
+func Catalog() []CatalogEntry {
+	return append([]CatalogEntry(nil), catalog...)
+}
+
+// ID returns the stable identifier of the code like "E0001".
+func (code Code) ID() string {
+	for _, entry := range catalog {
+		if entry.Code == code {
+			return entry.ID
+		}
+	}
+
+	return catalog[0].ID
+}
+
+// CatalogMarkdown renders the catalog as markdown reference.
+func CatalogMarkdown() string {
+	var builder strings.Builder
+
+	builder.WriteString("<!-- Code generated by go generate ./pkg/errs; DO NOT EDIT. -->\n\n")
+	builder.WriteString("# Error Codes\n\n")
+	builder.WriteString("Every error of goeval has a stable code, use `errs.CodeOf(err).ID()` to get it.\n\n")
+	builder.WriteString("| ID | Name | Description |\n")
+	builder.WriteString("|---|---|---|\n")
+
+	for _, entry := range catalog {
+		builder.WriteString(fmt.Sprintf("| %s | `%s` | %s |\n", entry.ID, entry.Code, entry.Description))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,56 @@
+package errs
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalog(t *testing.T) {
+	t.Parallel()
+
+	codes := map[Code]bool{}
+
+	for i, entry := range Catalog() {
+		require.Equal(t, fmt.Sprintf("E%04d", i), entry.ID, "IDs must be sequential")
+		require.False(t, codes[entry.Code], "code %q is listed twice", entry.Code)
+		require.NotEmpty(t, entry.Description)
+
+		codes[entry.Code] = true
+	}
+
+	for static, code := range staticCodes {
+		require.True(t, codes[code], "code of %q is missing", static)
+	}
+
+	for _, err := range []interface{ Code() Code }{
+		NewErrUnexpectedTokenType("", ""),
+		NewErrUnexpectedInputEnd(""),
+		NewErrUnexpectedCharacter(""),
+		NewErrMalformedNumber(""),
+		NewErrInvalidEscape(""),
+		NewErrUnknownIdentifier(""),
+		NewErrUnknownFunction(""),
+		NewErrPanic(nil, nil),
+	} {
+		require.True(t, codes[err.Code()], "code %q is missing", err.Code())
+	}
+}
+
+func TestCode_ID(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "E0001", CodeUnexpectedToken.ID())
+	require.Equal(t, "E0002", CodeDivisionByZero.ID())
+	require.Equal(t, "E0000", Code("not_existing").ID())
+}
+
+func TestCatalogMarkdown_UpToDate(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile("../../docs/errors.md")
+	require.NoError(t, err)
+	require.Equal(t, CatalogMarkdown(), string(content), "run go generate ./pkg/errs")
+}
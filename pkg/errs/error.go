@@ -0,0 +1,88 @@
+package errs
+
+import (
+	"context"
+	"errors"
+)
+
+// Code identifies the kind of an error independent of its message.
+type Code string
+
+const (
+	CodeUnknown             Code = "unknown"
+	CodeEmptyExpression     Code = "empty_expression"
+	CodeUnexpectedToken     Code = "unexpected_token"
+	CodeUnexpectedInputEnd  Code = "unexpected_input_end"
+	CodeUnexpectedCharacter Code = "unexpected_character"
+	CodeMalformedNumber     Code = "malformed_number"
+	CodeNumberOutOfRange    Code = "number_out_of_range"
+	CodeInvalidEscape       Code = "invalid_escape"
+	CodeUnknownIdentifier   Code = "unknown_identifier"
+	CodeUnknownFunction     Code = "unknown_function"
+	CodeDivisionByZero      Code = "division_by_zero"
+	CodeIntegerOverflow     Code = "integer_overflow"
+	CodeNotInteger          Code = "not_integer"
+	CodeNegativeShift       Code = "negative_shift"
+	CodePanic               Code = "panic"
+	CodeCanceled            Code = "canceled"
+	CodeDeadlineExceeded    Code = "deadline_exceeded"
+	CodeSourceTooLong       Code = "source_too_long"
+	CodeMaxDepth            Code = "max_depth"
+	CodeMaxSteps            Code = "max_steps"
+	CodeStringTooLong       Code = "string_too_long"
+	CodeMaxCost             Code = "max_cost"
+	CodeColumnLength        Code = "column_length"
+)
+
+// Error is implemented by errors located in the source of an expression.
+// Use errors.As to get it from an error and errors.Is or errors.As
+// on Unwrap to branch on the kind of error.
+type Error interface {
+	error
+	// Code of the wrapped error.
+	Code() Code
+	// Span of the source the error is located at.
+	Span() Span
+	// Unwrap returns the wrapped error.
+	Unwrap() error
+}
+
+var (
+	_ Error = ErrorAtPositionError{}
+	_ Error = RowError{}
+)
+
+// staticCodes maps the static errors to their code.
+var staticCodes = map[error]Code{
+	ErrEmptyExpression:  CodeEmptyExpression,
+	ErrDivisionByZero:   CodeDivisionByZero,
+	ErrIntegerOverflow:  CodeIntegerOverflow,
+	ErrNotInteger:       CodeNotInteger,
+	ErrNegativeShift:    CodeNegativeShift,
+	ErrNumberOutOfRange: CodeNumberOutOfRange,
+	ErrSourceTooLong:    CodeSourceTooLong,
+	ErrMaxDepth:         CodeMaxDepth,
+	ErrMaxSteps:         CodeMaxSteps,
+	ErrStringTooLong:    CodeStringTooLong,
+	ErrMaxCost:          CodeMaxCost,
+	ErrColumnLength:     CodeColumnLength,
+
+	context.Canceled:         CodeCanceled,
+	context.DeadlineExceeded: CodeDeadlineExceeded,
+}
+
+// CodeOf returns the code of the first error in the chain that has one.
+func CodeOf(err error) Code {
+	var coder interface{ Code() Code }
+	if errors.As(err, &coder) {
+		return coder.Code()
+	}
+
+	for static, code := range staticCodes {
+		if errors.Is(err, static) {
+			return code
+		}
+	}
+
+	return CodeUnknown
+}
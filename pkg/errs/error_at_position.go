@@ -1,24 +1,68 @@
 package errs
 
-import "fmt"
+const (
+	errErrorAtPosition = "%s, at position %d"
+	errErrorAtLine     = "%s, at line %d, column %d"
+)
 
-const errErrorAtPosition = "%s, at position %d"
-
-// ErrorAtPositionError is an error.
+// ErrorAtPositionError is an error
+// located at a span of the source.
 type ErrorAtPositionError struct {
-	err      error
-	position int
+	err  error
+	span Span
 }
 
 // Error returns the error message text.
 func (err ErrorAtPositionError) Error() string {
-	return fmt.Sprintf(errErrorAtPosition, err.err.Error(), err.position)
+	return err.Localize(English)
+}
+
+// Localize returns the error message text in the language of the localizer.
+func (err ErrorAtPositionError) Localize(localizer Localizer) string {
+	message := Localize(err.err, localizer)
+	if err.span.Start.Line == 0 {
+		return sprintf(localizer, MessageErrorAtPosition, message, err.span.Start.Offset)
+	}
+
+	return sprintf(localizer, MessageErrorAtLine, message, err.span.Start.Line, err.span.Start.Column)
+}
+
+// Unwrap returns the located error.
+func (err ErrorAtPositionError) Unwrap() error {
+	return err.err
+}
+
+// Code returns the code of the located error.
+func (err ErrorAtPositionError) Code() Code {
+	return CodeOf(err.err)
 }
 
-// NewErrorAtPosition cerate a new error.
+// Span returns the span of the source the error is located at.
+func (err ErrorAtPositionError) Span() Span {
+	return err.span
+}
+
+// Position returns the byte offset the error starts at.
+func (err ErrorAtPositionError) Position() int {
+	return err.span.Start.Offset
+}
+
+// NewErrorAtPosition cerate a new error at the byte offset,
+// without line and column information.
 func NewErrorAtPosition(err error, position int) ErrorAtPositionError {
 	return ErrorAtPositionError{
-		err:      err,
-		position: position,
+		err: err,
+		span: Span{
+			Start: Position{Offset: position},
+			End:   Position{Offset: position},
+		},
+	}
+}
+
+// NewErrorAtSpan cerate a new error at the span.
+func NewErrorAtSpan(err error, span Span) ErrorAtPositionError {
+	return ErrorAtPositionError{
+		err:  err,
+		span: span,
 	}
 }
@@ -17,3 +17,17 @@ func TestErrorAtPositionError(t *testing.T) {
 		NewErrorAtPosition(fmt.Errorf(key), pos).Error(),
 	)
 }
+
+func TestErrorAtSpanError(t *testing.T) {
+	t.Parallel()
+
+	key := "b"
+	span := NewSpan("1 +\n  x", 6, 7)
+	err := NewErrorAtSpan(fmt.Errorf(key), span)
+
+	require.Equal(t, fmt.Sprintf(errErrorAtLine, key, 2, 3), err.Error())
+	require.Equal(t, span, err.Span())
+	require.Equal(t, 6, err.Position())
+	require.Equal(t, CodeUnknown, err.Code())
+	require.EqualError(t, err.Unwrap(), key)
+}
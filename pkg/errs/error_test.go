@@ -0,0 +1,50 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeOf(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name   string
+		err    error
+		expect Code
+	}{
+		{name: "Static", err: ErrDivisionByZero, expect: CodeDivisionByZero},
+		{name: "Typed", err: NewErrUnexpectedTokenType("a", "b"), expect: CodeUnexpectedToken},
+		{name: "Positioned_Static", err: NewErrorAtPosition(ErrIntegerOverflow, 1), expect: CodeIntegerOverflow},
+		{name: "Positioned_Typed", err: NewErrorAtPosition(NewErrInvalidEscape(`\q`), 1), expect: CodeInvalidEscape},
+		{name: "Wrapped", err: fmt.Errorf("eval: %w", NewErrorAtPosition(ErrNotInteger, 1)), expect: CodeNotInteger},
+		{name: "Unknown", err: errors.New("other"), expect: CodeUnknown},
+	}
+
+	for _, tc := range tcs {
+		tcRef := tc
+
+		t.Run(tcRef.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tcRef.expect, CodeOf(tcRef.err))
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	span := NewSpan("1 / 0", 2, 3)
+	err := fmt.Errorf("eval: %w", NewErrorAtSpan(ErrDivisionByZero, span))
+
+	var located Error
+
+	require.ErrorIs(t, err, ErrDivisionByZero)
+	require.ErrorAs(t, err, &located)
+	require.Equal(t, CodeDivisionByZero, located.Code())
+	require.Equal(t, span, located.Span())
+	require.Equal(t, ErrDivisionByZero, located.Unwrap())
+}
@@ -0,0 +1,163 @@
+package errs
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	ansiReset = "\033[0m"
+	ansiBold  = "\033[1m"
+	ansiRed   = "\033[31m"
+	ansiBlue  = "\033[34m"
+)
+
+// FormatOption configures Format.
+type FormatOption func(*formatConfig)
+
+type formatConfig struct {
+	color     bool
+	localizer Localizer
+}
+
+// WithColor highlights the output with ANSI colors for terminals.
+func WithColor() FormatOption {
+	return func(c *formatConfig) {
+		c.color = true
+	}
+}
+
+// WithLocalizer renders the messages in the language of the localizer.
+func WithLocalizer(localizer Localizer) FormatOption {
+	return func(c *formatConfig) {
+		c.localizer = localizer
+	}
+}
+
+// Format renders the error for humans. If the error is located in the source,
+// the source line is printed with the located part underlined by carets:
+//
+//	error: unexpected ')', expected literal
+//	 --> line 1, column 6
+//	  |
+//	1 | (1 + )
+//	  |      ^
+//
+// The errors of a List are rendered one after another.
+func Format(err error, source string, opts ...FormatOption) string {
+	cfg := &formatConfig{localizer: English}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	var list List
+	if errors.As(err, &list) && len(list) > 0 {
+		formatted := make([]string, 0, len(list))
+		for _, err := range list {
+			formatted = append(formatted, Format(err, source, opts...))
+		}
+
+		return strings.Join(formatted, "\n\n")
+	}
+
+	var positioned ErrorAtPositionError
+	if !errors.As(err, &positioned) || positioned.span.Start.Offset > len(source) {
+		return cfg.paint(ansiBold+ansiRed, cfg.message(MessageFormatError)) +
+			cfg.paint(ansiBold, ": "+Localize(err, cfg.localizer))
+	}
+
+	span := positioned.span
+	if span.Start.Line == 0 {
+		span = NewSpan(source, span.Start.Offset, span.End.Offset)
+	}
+
+	lineStart := strings.LastIndexByte(source[:span.Start.Offset], '\n') + 1
+	lineEnd := strings.IndexByte(source[lineStart:], '\n')
+
+	if lineEnd == -1 {
+		lineEnd = len(source)
+	} else {
+		lineEnd += lineStart
+	}
+
+	number := strconv.Itoa(span.Start.Line)
+	gutter := strings.Repeat(" ", len(number))
+	snippet := source[span.Start.Offset:min(max(span.End.Offset, span.Start.Offset), lineEnd)]
+	underline := max(utf8.RuneCountInString(snippet), 1)
+
+	var builder strings.Builder
+
+	builder.WriteString(cfg.paint(ansiBold+ansiRed, cfg.message(MessageFormatError)))
+	builder.WriteString(cfg.paint(ansiBold, ": "+cfg.humanMessage(positioned.err, snippet)))
+	builder.WriteString("\n" + gutter + cfg.paint(ansiBlue, "-->") + " ")
+	builder.WriteString(cfg.message(MessageFormatLocation, span.Start.Line, span.Start.Column) + "\n")
+	builder.WriteString(cfg.paint(ansiBlue, gutter+" |") + "\n")
+	builder.WriteString(cfg.paint(ansiBlue, number+" |") + " " + source[lineStart:lineEnd] + "\n")
+	builder.WriteString(cfg.paint(ansiBlue, gutter+" |") + " ")
+	builder.WriteString(indentation(source[lineStart:span.Start.Offset]))
+	builder.WriteString(cfg.paint(ansiBold+ansiRed, strings.Repeat("^", underline)))
+
+	return builder.String()
+}
+
+func (c *formatConfig) message(id MessageID, a ...any) string {
+	return sprintf(c.localizer, id, a...)
+}
+
+func (c *formatConfig) paint(color, text string) string {
+	if !c.color {
+		return text
+	}
+
+	return color + text + ansiReset
+}
+
+// indentation replaces everything but tabs by spaces,
+// so the carets align with the line above.
+func indentation(prefix string) string {
+	var builder strings.Builder
+
+	for _, char := range prefix {
+		if char == '\t' {
+			builder.WriteRune(char)
+		} else {
+			builder.WriteByte(' ')
+		}
+	}
+
+	return builder.String()
+}
+
+// humanMessage describes the error without internal token type names,
+// the snippet is the located part of the source.
+func (c *formatConfig) humanMessage(err error, snippet string) string {
+	var (
+		unexpectedToken UnexpectedTokenTypeError
+		unexpectedEnd   UnexpectedInputEndError
+	)
+
+	switch {
+	case errors.As(err, &unexpectedToken):
+		actual := c.humanTokenName(unexpectedToken.actual)
+		if snippet != "" {
+			actual = c.message(MessageFormatUnexpectedQuoted, snippet)
+		}
+
+		return c.message(MessageFormatUnexpectedToken, actual, c.humanTokenName(unexpectedToken.expected))
+	case errors.As(err, &unexpectedEnd):
+		return c.message(MessageFormatUnexpectedEnd, c.humanTokenName(unexpectedEnd.tokenType))
+	}
+
+	return Localize(err, c.localizer)
+}
+
+// humanTokenName returns the localized name of the token type.
+func (c *formatConfig) humanTokenName(tokenType string) string {
+	if name := sprintf(c.localizer, TokenMessage(tokenType)); name != "" {
+		return name
+	}
+
+	return strings.ToLower(strings.ReplaceAll(tokenType, "_", " "))
+}
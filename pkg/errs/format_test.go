@@ -0,0 +1,126 @@
+package errs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Unexpected_Token", func(t *testing.T) {
+		t.Parallel()
+
+		source := "(1 + )"
+		err := NewErrorAtSpan(NewErrUnexpectedTokenType("CONTEXT_END", "literal"), NewSpan(source, 5, 6))
+		require.Equal(t, ""+
+			"error: unexpected ')', expected literal\n"+
+			" --> line 1, column 6\n"+
+			"  |\n"+
+			"1 | (1 + )\n"+
+			"  |      ^",
+			Format(err, source))
+	})
+
+	t.Run("Unexpected_Input_End", func(t *testing.T) {
+		t.Parallel()
+
+		source := "(1 + 2"
+		err := NewErrorAtSpan(NewErrUnexpectedInputEnd("CONTEXT_END"), NewSpan(source, 6, 6))
+		require.Equal(t, ""+
+			"error: unexpected end of input, expected ')'\n"+
+			" --> line 1, column 7\n"+
+			"  |\n"+
+			"1 | (1 + 2\n"+
+			"  |       ^",
+			Format(err, source))
+	})
+
+	t.Run("Multi_Line_Underline", func(t *testing.T) {
+		t.Parallel()
+
+		source := "true &&\n\t'ä' // 0\n|| false"
+		err := NewErrorAtSpan(ErrDivisionByZero, NewSpan(source, 14, 16))
+		require.Equal(t, ""+
+			"error: division by zero\n"+
+			" --> line 2, column 6\n"+
+			"  |\n"+
+			"2 | \t'ä' // 0\n"+
+			"  | \t    ^^",
+			Format(err, source))
+	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Parallel()
+
+		source := "(1 + ) * (2"
+		err := List{
+			NewErrorAtSpan(NewErrUnexpectedTokenType("CONTEXT_END", "literal"), NewSpan(source, 5, 6)),
+			NewErrorAtSpan(NewErrUnexpectedInputEnd("CONTEXT_END"), NewSpan(source, 11, 11)),
+		}
+		require.Equal(t, ""+
+			"error: unexpected ')', expected literal\n"+
+			" --> line 1, column 6\n"+
+			"  |\n"+
+			"1 | (1 + ) * (2\n"+
+			"  |      ^\n"+
+			"\n"+
+			"error: unexpected end of input, expected ')'\n"+
+			" --> line 1, column 12\n"+
+			"  |\n"+
+			"1 | (1 + ) * (2\n"+
+			"  |            ^",
+			Format(err, source))
+	})
+
+	t.Run("Position_Only", func(t *testing.T) {
+		t.Parallel()
+
+		source := "1 / 0"
+		err := NewErrorAtPosition(ErrDivisionByZero, 2)
+		require.Equal(t, ""+
+			"error: division by zero\n"+
+			" --> line 1, column 3\n"+
+			"  |\n"+
+			"1 | 1 / 0\n"+
+			"  |   ^",
+			Format(err, source))
+	})
+
+	t.Run("Without_Position", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t, "error: empty expression", Format(ErrEmptyExpression, ""))
+	})
+
+	t.Run("Localized", func(t *testing.T) {
+		t.Parallel()
+
+		source := "(1 + )"
+		err := NewErrorAtSpan(NewErrUnexpectedTokenType("CONTEXT_END", "literal"), NewSpan(source, 5, 6))
+		require.Equal(t, ""+
+			"Fehler: unerwartet ')', erwartet Literal\n"+
+			" --> Zeile 1, Spalte 6\n"+
+			"  |\n"+
+			"1 | (1 + )\n"+
+			"  |      ^",
+			Format(err, source, WithLocalizer(German)))
+	})
+
+	t.Run("Color", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t,
+			ansiBold+ansiRed+"error"+ansiReset+ansiBold+": empty expression"+ansiReset,
+			Format(ErrEmptyExpression, "", WithColor()))
+	})
+}
+
+func TestHumanTokenName(t *testing.T) {
+	t.Parallel()
+
+	cfg := &formatConfig{localizer: German}
+
+	require.Equal(t, "')'", cfg.humanTokenName("CONTEXT_END"))
+	require.Equal(t, "Zahl", cfg.humanTokenName("NUMBER"))
+	require.Equal(t, "some token", cfg.humanTokenName("SOME_TOKEN"))
+}
@@ -0,0 +1,21 @@
+// Command catalogdoc writes the error code reference to the given file.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+)
+
+const filePermission = 0o644
+
+func main() {
+	if len(os.Args) != 2 {
+		log.Fatal("usage: catalogdoc <output file>")
+	}
+
+	if err := os.WriteFile(os.Args[1], []byte(errs.CatalogMarkdown()), filePermission); err != nil {
+		log.Fatal(err)
+	}
+}
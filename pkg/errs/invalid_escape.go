@@ -0,0 +1,39 @@
+package errs
+
+const errInvalidEscapeMessage = "Invalid escape sequence: \"%s\""
+
+// InvalidEscapeError is an error
+// type for invalid escape sequences in text.
+type InvalidEscapeError struct {
+	sequence string
+}
+
+// Error returns the error message text.
+func (err InvalidEscapeError) Error() string {
+	return err.Localize(English)
+}
+
+// Localize returns the error message text in the language of the localizer.
+func (err InvalidEscapeError) Localize(localizer Localizer) string {
+	return sprintf(localizer, CodeMessage(CodeInvalidEscape), err.sequence)
+}
+
+// Sequence returns the invalid escape sequence.
+func (err InvalidEscapeError) Sequence() string {
+	return err.sequence
+}
+
+// Code returns CodeInvalidEscape.
+func (err InvalidEscapeError) Code() Code {
+	return CodeInvalidEscape
+}
+
+// Details returns the fields of the error.
+func (err InvalidEscapeError) Details() map[string]any {
+	return map[string]any{"sequence": err.sequence}
+}
+
+// NewErrInvalidEscape cerate a new error.
+func NewErrInvalidEscape(sequence string) InvalidEscapeError {
+	return InvalidEscapeError{sequence: sequence}
+}
@@ -0,0 +1,21 @@
+package errs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrInvalidEscape(t *testing.T) {
+	t.Parallel()
+
+	key := `\q`
+	err := NewErrInvalidEscape(key)
+	require.Equal(t,
+		fmt.Sprintf(errInvalidEscapeMessage, key),
+		err.Error(),
+	)
+	require.Equal(t, key, err.Sequence())
+	require.Equal(t, CodeInvalidEscape, err.Code())
+}
@@ -0,0 +1,45 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// JSON is the machine-readable form of an error, e.g. for HTTP responses.
+type JSON struct {
+	// Code is the stable ID like "E0002".
+	Code string `json:"code"`
+	// Name is the code like "division_by_zero".
+	Name    Code           `json:"name"`
+	Message string         `json:"message"`
+	Span    *Span          `json:"span,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// ToJSON converts any error to its machine-readable form.
+func ToJSON(err error) JSON {
+	code := CodeOf(err)
+	result := JSON{
+		Code:    code.ID(),
+		Name:    code,
+		Message: err.Error(),
+	}
+
+	var located Error
+	if errors.As(err, &located) {
+		span := located.Span()
+		result.Span = &span
+	}
+
+	var detailer interface{ Details() map[string]any }
+	if errors.As(err, &detailer) {
+		result.Details = detailer.Details()
+	}
+
+	return result
+}
+
+// MarshalJSON encodes the error as JSON.
+func (err ErrorAtPositionError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ToJSON(err))
+}
@@ -0,0 +1,39 @@
+package errs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Located", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewErrorAtSpan(NewErrUnexpectedTokenType("CONTEXT_END", "literal"), NewSpan("(1 + )", 5, 6))
+		content, marshalErr := json.Marshal(err)
+		require.NoError(t, marshalErr)
+		require.JSONEq(t, `{
+			"code": "E0001",
+			"name": "unexpected_token",
+			"message": "Unexpected token: \"CONTEXT_END\", expected: \"literal\", at line 1, column 6",
+			"span": {
+				"start": {"offset": 5, "line": 1, "column": 6},
+				"end": {"offset": 6, "line": 1, "column": 7}
+			},
+			"details": {"actual": "CONTEXT_END", "expected": "literal"}
+		}`, string(content))
+	})
+
+	t.Run("Static", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t, JSON{
+			Code:    "E0005",
+			Name:    CodeEmptyExpression,
+			Message: ErrEmptyExpression.Error(),
+		}, ToJSON(ErrEmptyExpression))
+	})
+}
@@ -0,0 +1,44 @@
+package errs
+
+const errListMessage = "%s (and %d more errors)"
+
+// List is a list of errors, e.g. all syntax errors of an expression
+// in the order of the source. It works with errors.Is and errors.As
+// for each of the errors.
+type List []error
+
+// Error returns the message of the first error
+// and how many errors follow.
+func (list List) Error() string {
+	return list.Localize(English)
+}
+
+// Localize returns the error message text in the language of the localizer.
+func (list List) Localize(localizer Localizer) string {
+	switch len(list) {
+	case 0:
+		return sprintf(localizer, MessageNoErrors)
+	case 1:
+		return Localize(list[0], localizer)
+	}
+
+	return sprintf(localizer, MessageList, Localize(list[0], localizer), len(list)-1)
+}
+
+// Unwrap returns the errors of the list.
+func (list List) Unwrap() []error {
+	return list
+}
+
+// Err returns nil for an empty list, the error itself
+// for a single error and the list otherwise.
+func (list List) Err() error {
+	switch len(list) {
+	case 0:
+		return nil
+	case 1:
+		return list[0]
+	}
+
+	return list
+}
@@ -0,0 +1,31 @@
+package errs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	first := NewErrorAtPosition(NewErrUnexpectedInputEnd("literal"), 3)
+	list := List{first, NewErrorAtPosition(ErrDivisionByZero, 5)}
+
+	require.Equal(t, fmt.Sprintf(errListMessage, first.Error(), 1), list.Error())
+	require.ErrorIs(t, list, ErrDivisionByZero)
+	require.Equal(t, CodeUnexpectedInputEnd, CodeOf(list))
+
+	var unexpected UnexpectedInputEndError
+
+	require.ErrorAs(t, list, &unexpected)
+}
+
+func TestList_Err(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, List{}.Err())
+	require.Equal(t, ErrDivisionByZero, List{ErrDivisionByZero}.Err())
+	require.Equal(t, List{ErrDivisionByZero, ErrNotInteger}, List{ErrDivisionByZero, ErrNotInteger}.Err())
+}
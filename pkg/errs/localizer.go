@@ -0,0 +1,97 @@
+package errs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MessageID identifies a message of the catalog. The messages of errors are
+// identified by their code (see CodeMessage) and human token names by their
+// token type (see TokenMessage).
+type MessageID string
+
+const (
+	MessageErrorAtPosition        MessageID = "error_at_position"
+	MessageErrorAtLine            MessageID = "error_at_line"
+	MessageErrorInRow             MessageID = "error_in_row"
+	MessageDidYouMean             MessageID = "did_you_mean"
+	MessageOr                     MessageID = "or"
+	MessageList                   MessageID = "list"
+	MessageNoErrors               MessageID = "no_errors"
+	MessageFormatError            MessageID = "format_error"
+	MessageFormatLocation         MessageID = "format_location"
+	MessageFormatUnexpectedToken  MessageID = "format_unexpected_token"
+	MessageFormatUnexpectedEnd    MessageID = "format_unexpected_end"
+	MessageFormatUnexpectedQuoted MessageID = "format_unexpected_quoted"
+)
+
+// Localizer provides the messages in a language as fmt formats,
+// which take the same arguments as the English ones.
+type Localizer interface {
+	// Message returns the format of the message or "" if it is not translated.
+	Message(id MessageID) string
+}
+
+// Messages is a Localizer of a fixed set of messages.
+type Messages map[MessageID]string
+
+// Message returns the format of the message or "" if it is not translated.
+func (messages Messages) Message(id MessageID) string {
+	return messages[id]
+}
+
+// CodeMessage returns the ID of the message of errors with the code.
+func CodeMessage(code Code) MessageID {
+	return MessageID(code)
+}
+
+// TokenMessage returns the ID of the human name of the token type.
+func TokenMessage(tokenType string) MessageID {
+	return MessageID("token." + tokenType)
+}
+
+// localizable errors render their message with a Localizer.
+type localizable interface {
+	Localize(localizer Localizer) string
+}
+
+// Localize renders the message of the error with the localizer, missing
+// messages fall back to English. Errors that are not of goeval are rendered
+// by their Error method.
+func Localize(err error, localizer Localizer) string {
+	if err, ok := err.(localizable); ok {
+		return err.Localize(localizer)
+	}
+
+	for static, code := range staticCodes {
+		if err == static {
+			return sprintf(localizer, CodeMessage(code))
+		}
+	}
+
+	return err.Error()
+}
+
+// sprintf formats the message of the localizer, or the English one if it is missing.
+func sprintf(localizer Localizer, id MessageID, a ...any) string {
+	format := ""
+	if localizer != nil {
+		format = localizer.Message(id)
+	}
+
+	if format == "" {
+		format = English[id]
+	}
+
+	return fmt.Sprintf(format, a...)
+}
+
+// quoteAll quotes the values and joins them with the localized "or".
+func quoteAll(localizer Localizer, values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+
+	return strings.Join(quoted, sprintf(localizer, MessageOr))
+}
@@ -0,0 +1,66 @@
+package errs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnglish_Complete(t *testing.T) {
+	t.Parallel()
+
+	for _, entry := range Catalog() {
+		if entry.Code != CodeUnknown {
+			require.NotEmpty(t, English.Message(CodeMessage(entry.Code)), "message of %q is missing", entry.Code)
+		}
+	}
+
+	for static := range staticCodes {
+		require.Equal(t, static.Error(), Localize(static, English))
+	}
+}
+
+func TestTranslations(t *testing.T) {
+	t.Parallel()
+
+	for name, messages := range map[string]Messages{"German": German, "French": French} {
+		for id, format := range messages {
+			english, ok := English[id]
+			require.True(t, ok, "%s message %q is not in English", name, id)
+			require.Equal(t, strings.Count(english, "%"), strings.Count(format, "%"),
+				"%s message %q has other arguments than in English", name, id)
+		}
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	t.Parallel()
+
+	located := NewErrorAtSpan(NewErrUnknownIdentifier("countr", "country", "count"), Span{
+		Start: Position{Offset: 0, Line: 1, Column: 1},
+		End:   Position{Offset: 6, Line: 1, Column: 7},
+	})
+
+	require.Equal(t, located.Error(), Localize(located, English))
+	require.Equal(t, located.Error(), Localize(located, nil))
+	require.Equal(t,
+		`Unbekannter Bezeichner: "countr", meinten Sie "country" oder "count"?, in Zeile 1, Spalte 1`,
+		Localize(located, German))
+	require.Equal(t,
+		`division par zéro, à la position 3 (et 1 autres erreurs)`,
+		Localize(List{NewErrorAtPosition(ErrDivisionByZero, 3), located}, French))
+	require.Equal(t, `Ungültige Escape-Sequenz: "\q"`, Localize(NewErrInvalidEscape(`\q`), German))
+	require.Equal(t, "leerer Ausdruck", Localize(ErrEmptyExpression, German))
+}
+
+func TestLocalize_Fallback(t *testing.T) {
+	t.Parallel()
+
+	foreign := errors.New("custom error")
+	require.Equal(t, "custom error", Localize(foreign, German))
+
+	partial := Messages{MessageErrorAtPosition: "%s @ %d"}
+	require.Equal(t, "division by zero @ 3", Localize(NewErrorAtPosition(ErrDivisionByZero, 3), partial))
+}
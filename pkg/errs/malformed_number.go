@@ -0,0 +1,39 @@
+package errs
+
+const errMalformedNumberMessage = "Malformed number: \"%s\""
+
+// MalformedNumberError is an error
+// type for malformed number literals.
+type MalformedNumberError struct {
+	literal string
+}
+
+// Error returns the error message text.
+func (err MalformedNumberError) Error() string {
+	return err.Localize(English)
+}
+
+// Localize returns the error message text in the language of the localizer.
+func (err MalformedNumberError) Localize(localizer Localizer) string {
+	return sprintf(localizer, CodeMessage(CodeMalformedNumber), err.literal)
+}
+
+// Literal returns the malformed number literal.
+func (err MalformedNumberError) Literal() string {
+	return err.literal
+}
+
+// Code returns CodeMalformedNumber.
+func (err MalformedNumberError) Code() Code {
+	return CodeMalformedNumber
+}
+
+// Details returns the fields of the error.
+func (err MalformedNumberError) Details() map[string]any {
+	return map[string]any{"literal": err.literal}
+}
+
+// NewErrMalformedNumber cerate a new error.
+func NewErrMalformedNumber(literal string) MalformedNumberError {
+	return MalformedNumberError{literal: literal}
+}
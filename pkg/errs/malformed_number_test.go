@@ -0,0 +1,21 @@
+package errs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrMalformedNumber(t *testing.T) {
+	t.Parallel()
+
+	key := "1__0"
+	err := NewErrMalformedNumber(key)
+	require.Equal(t,
+		fmt.Sprintf(errMalformedNumberMessage, key),
+		err.Error(),
+	)
+	require.Equal(t, key, err.Literal())
+	require.Equal(t, CodeMalformedNumber, err.Code())
+}
@@ -0,0 +1,52 @@
+package errs
+
+// German messages.
+var German = Messages{
+	MessageErrorAtPosition:        "%s, an Position %d",
+	MessageErrorAtLine:            "%s, in Zeile %d, Spalte %d",
+	MessageErrorInRow:             "%s, in Datenzeile %d",
+	MessageDidYouMean:             "%s, meinten Sie %s?",
+	MessageOr:                     " oder ",
+	MessageList:                   "%s (und %d weitere Fehler)",
+	MessageNoErrors:               "keine Fehler",
+	MessageFormatError:            "Fehler",
+	MessageFormatLocation:         "Zeile %d, Spalte %d",
+	MessageFormatUnexpectedToken:  "unerwartet %s, erwartet %s",
+	MessageFormatUnexpectedEnd:    "unerwartetes Ende der Eingabe, erwartet %s",
+	MessageFormatUnexpectedQuoted: "'%s'",
+
+	CodeMessage(CodeEmptyExpression):     "leerer Ausdruck",
+	CodeMessage(CodeUnexpectedToken):     "Unerwartetes Token: \"%s\", erwartet: \"%s\"",
+	CodeMessage(CodeUnexpectedInputEnd):  "Unerwartetes Ende der Eingabe, erwartet: \"%s\"",
+	CodeMessage(CodeUnexpectedCharacter): "Unerwartetes Zeichen: \"%s\"",
+	CodeMessage(CodeMalformedNumber):     "Fehlerhafte Zahl: \"%s\"",
+	CodeMessage(CodeNumberOutOfRange):    "Zahl außerhalb des Wertebereichs",
+	CodeMessage(CodeInvalidEscape):       "Ungültige Escape-Sequenz: \"%s\"",
+	CodeMessage(CodeUnknownIdentifier):   "Unbekannter Bezeichner: \"%s\"",
+	CodeMessage(CodeUnknownFunction):     "Unbekannte Funktion: \"%s\"",
+	CodeMessage(CodeDivisionByZero):      "Division durch null",
+	CodeMessage(CodeIntegerOverflow):     "Ganzzahlüberlauf",
+	CodeMessage(CodeNotInteger):          "Operation erfordert ganzzahlige Operanden",
+	CodeMessage(CodeNegativeShift):       "negative Verschiebung",
+	CodeMessage(CodePanic):               "Panik: %v",
+	CodeMessage(CodeCanceled):            "Kontext abgebrochen",
+	CodeMessage(CodeDeadlineExceeded):    "Kontext-Frist überschritten",
+	CodeMessage(CodeSourceTooLong):       "Quelltext überschreitet die maximale Länge",
+	CodeMessage(CodeMaxDepth):            "Verschachtelung überschreitet die maximale Tiefe",
+	CodeMessage(CodeMaxSteps):            "Auswertung überschreitet die maximalen Schritte",
+	CodeMessage(CodeStringTooLong):       "Text überschreitet die maximale Länge",
+	CodeMessage(CodeMaxCost):             "geschätzte Kosten überschreiten das Maximum",
+	CodeMessage(CodeColumnLength):        "Spalten haben unterschiedliche Längen",
+
+	TokenMessage("ARITHMETIC_OPERATION"): "arithmetische Operation",
+	TokenMessage("BITWISE_OPERATION"):    "bitweise Operation",
+	TokenMessage("COMPARISON_OPERATION"): "Vergleichsoperation",
+	TokenMessage("LOGICAL_OPERATION"):    "logische Operation",
+	TokenMessage("NUMBER"):               "Zahl",
+	TokenMessage("BOOL"):                 "Wahrheitswert",
+	TokenMessage("TEXT"):                 "Text",
+	TokenMessage("IDENTIFIER"):           "Bezeichner",
+	TokenMessage("literal"):              "Literal",
+	TokenMessage("operation"):            "Operation",
+	TokenMessage("any"):                  "beliebiges Token",
+}
@@ -0,0 +1,55 @@
+package errs
+
+// English messages are the default.
+var English = Messages{
+	MessageErrorAtPosition:        errErrorAtPosition,
+	MessageErrorAtLine:            errErrorAtLine,
+	MessageErrorInRow:             errErrorInRow,
+	MessageDidYouMean:             errDidYouMeanMessage,
+	MessageOr:                     " or ",
+	MessageList:                   errListMessage,
+	MessageNoErrors:               "no errors",
+	MessageFormatError:            "error",
+	MessageFormatLocation:         "line %d, column %d",
+	MessageFormatUnexpectedToken:  "unexpected %s, expected %s",
+	MessageFormatUnexpectedEnd:    "unexpected end of input, expected %s",
+	MessageFormatUnexpectedQuoted: "'%s'",
+
+	CodeMessage(CodeEmptyExpression):     "empty expression",
+	CodeMessage(CodeUnexpectedToken):     errUnexpectedTokenTypeMessage,
+	CodeMessage(CodeUnexpectedInputEnd):  errUnexpectedInputEndMessage,
+	CodeMessage(CodeUnexpectedCharacter): errUnexpectedCharacterMessage,
+	CodeMessage(CodeMalformedNumber):     errMalformedNumberMessage,
+	CodeMessage(CodeNumberOutOfRange):    "number out of range",
+	CodeMessage(CodeInvalidEscape):       errInvalidEscapeMessage,
+	CodeMessage(CodeUnknownIdentifier):   errUnknownIdentifierMessage,
+	CodeMessage(CodeUnknownFunction):     errUnknownFunctionMessage,
+	CodeMessage(CodeDivisionByZero):      "division by zero",
+	CodeMessage(CodeIntegerOverflow):     "integer overflow",
+	CodeMessage(CodeNotInteger):          "operation requires integer operands",
+	CodeMessage(CodeNegativeShift):       "negative shift amount",
+	CodeMessage(CodePanic):               errPanicMessage,
+	CodeMessage(CodeCanceled):            "context canceled",
+	CodeMessage(CodeDeadlineExceeded):    "context deadline exceeded",
+	CodeMessage(CodeSourceTooLong):       "source exceeds the maximum length",
+	CodeMessage(CodeMaxDepth):            "nesting exceeds the maximum depth",
+	CodeMessage(CodeMaxSteps):            "evaluation exceeds the maximum steps",
+	CodeMessage(CodeStringTooLong):       "text exceeds the maximum length",
+	CodeMessage(CodeMaxCost):             "estimated cost exceeds the maximum",
+	CodeMessage(CodeColumnLength):        "columns have different lengths",
+
+	TokenMessage("CONTEXT_START"):        "'('",
+	TokenMessage("CONTEXT_END"):          "')'",
+	TokenMessage("SEPARATOR"):            "','",
+	TokenMessage("ARITHMETIC_OPERATION"): "arithmetic operation",
+	TokenMessage("BITWISE_OPERATION"):    "bitwise operation",
+	TokenMessage("COMPARISON_OPERATION"): "comparison operation",
+	TokenMessage("LOGICAL_OPERATION"):    "logical operation",
+	TokenMessage("NUMBER"):               "number",
+	TokenMessage("BOOL"):                 "boolean",
+	TokenMessage("TEXT"):                 "text",
+	TokenMessage("IDENTIFIER"):           "identifier",
+	TokenMessage("literal"):              "literal",
+	TokenMessage("operation"):            "operation",
+	TokenMessage("any"):                  "any token",
+}
@@ -0,0 +1,55 @@
+package errs
+
+// French messages.
+var French = Messages{
+	MessageErrorAtPosition:        "%s, à la position %d",
+	MessageErrorAtLine:            "%s, à la ligne %d, colonne %d",
+	MessageErrorInRow:             "%s, dans la rangée %d",
+	MessageDidYouMean:             "%s, vouliez-vous dire %s ?",
+	MessageOr:                     " ou ",
+	MessageList:                   "%s (et %d autres erreurs)",
+	MessageNoErrors:               "aucune erreur",
+	MessageFormatError:            "erreur",
+	MessageFormatLocation:         "ligne %d, colonne %d",
+	MessageFormatUnexpectedToken:  "%s inattendu, %s attendu",
+	MessageFormatUnexpectedEnd:    "fin inattendue de l'entrée, %s attendu",
+	MessageFormatUnexpectedQuoted: "« %s »",
+
+	CodeMessage(CodeEmptyExpression):     "expression vide",
+	CodeMessage(CodeUnexpectedToken):     "Jeton inattendu : \"%s\", attendu : \"%s\"",
+	CodeMessage(CodeUnexpectedInputEnd):  "Fin inattendue de l'entrée, attendu : \"%s\"",
+	CodeMessage(CodeUnexpectedCharacter): "Caractère inattendu : \"%s\"",
+	CodeMessage(CodeMalformedNumber):     "Nombre mal formé : \"%s\"",
+	CodeMessage(CodeNumberOutOfRange):    "nombre hors limites",
+	CodeMessage(CodeInvalidEscape):       "Séquence d'échappement invalide : \"%s\"",
+	CodeMessage(CodeUnknownIdentifier):   "Identifiant inconnu : \"%s\"",
+	CodeMessage(CodeUnknownFunction):     "Fonction inconnue : \"%s\"",
+	CodeMessage(CodeDivisionByZero):      "division par zéro",
+	CodeMessage(CodeIntegerOverflow):     "dépassement d'entier",
+	CodeMessage(CodeNotInteger):          "l'opération requiert des opérandes entiers",
+	CodeMessage(CodeNegativeShift):       "décalage négatif",
+	CodeMessage(CodePanic):               "Panique : %v",
+	CodeMessage(CodeCanceled):            "contexte annulé",
+	CodeMessage(CodeDeadlineExceeded):    "délai du contexte dépassé",
+	CodeMessage(CodeSourceTooLong):       "la source dépasse la longueur maximale",
+	CodeMessage(CodeMaxDepth):            "l'imbrication dépasse la profondeur maximale",
+	CodeMessage(CodeMaxSteps):            "l'évaluation dépasse le nombre maximal d'étapes",
+	CodeMessage(CodeStringTooLong):       "le texte dépasse la longueur maximale",
+	CodeMessage(CodeMaxCost):             "le coût estimé dépasse le maximum",
+	CodeMessage(CodeColumnLength):        "les colonnes ont des longueurs différentes",
+
+	TokenMessage("CONTEXT_START"):        "« ( »",
+	TokenMessage("CONTEXT_END"):          "« ) »",
+	TokenMessage("SEPARATOR"):            "« , »",
+	TokenMessage("ARITHMETIC_OPERATION"): "opération arithmétique",
+	TokenMessage("BITWISE_OPERATION"):    "opération bit à bit",
+	TokenMessage("COMPARISON_OPERATION"): "opération de comparaison",
+	TokenMessage("LOGICAL_OPERATION"):    "opération logique",
+	TokenMessage("NUMBER"):               "nombre",
+	TokenMessage("BOOL"):                 "booléen",
+	TokenMessage("TEXT"):                 "texte",
+	TokenMessage("IDENTIFIER"):           "identifiant",
+	TokenMessage("literal"):              "littéral",
+	TokenMessage("operation"):            "opération",
+	TokenMessage("any"):                  "n'importe quel jeton",
+}
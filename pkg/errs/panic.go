@@ -0,0 +1,59 @@
+package errs
+
+import "fmt"
+
+const errPanicMessage = "Panic: %v"
+
+// PanicError is an error type for panics
+// recovered from functions and resolvers.
+type PanicError struct {
+	value any
+	stack []byte
+}
+
+// Error returns the error message text.
+func (err PanicError) Error() string {
+	return err.Localize(English)
+}
+
+// Localize returns the error message text in the language of the localizer.
+func (err PanicError) Localize(localizer Localizer) string {
+	return sprintf(localizer, CodeMessage(CodePanic), err.value)
+}
+
+// Unwrap returns the value if the panic was called with an error.
+func (err PanicError) Unwrap() error {
+	if wrapped, ok := err.value.(error); ok {
+		return wrapped
+	}
+
+	return nil
+}
+
+// Value returns the value the panic was called with.
+func (err PanicError) Value() any {
+	return err.value
+}
+
+// Stack returns the stack trace of the goroutine when it panicked.
+func (err PanicError) Stack() []byte {
+	return err.stack
+}
+
+// Code returns CodePanic.
+func (err PanicError) Code() Code {
+	return CodePanic
+}
+
+// Details returns the fields of the error without the stack trace.
+func (err PanicError) Details() map[string]any {
+	return map[string]any{"value": fmt.Sprint(err.value)}
+}
+
+// NewErrPanic cerate a new error.
+func NewErrPanic(value any, stack []byte) PanicError {
+	return PanicError{
+		value: value,
+		stack: stack,
+	}
+}
@@ -0,0 +1,33 @@
+package errs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrPanic(t *testing.T) {
+	t.Parallel()
+
+	value := "index out of range"
+	stack := []byte("goroutine 1 [running]:")
+	err := NewErrPanic(value, stack)
+	require.Equal(t,
+		fmt.Sprintf(errPanicMessage, value),
+		err.Error(),
+	)
+	require.Equal(t, value, err.Value())
+	require.Equal(t, stack, err.Stack())
+	require.NoError(t, err.Unwrap())
+	require.Equal(t, CodePanic, err.Code())
+	require.Equal(t, map[string]any{"value": value}, err.Details())
+}
+
+func TestErrPanic_Error_Value(t *testing.T) {
+	t.Parallel()
+
+	err := NewErrPanic(ErrDivisionByZero, nil)
+	require.ErrorIs(t, err, ErrDivisionByZero)
+	require.Equal(t, CodePanic, CodeOf(err))
+}
@@ -0,0 +1,71 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+)
+
+const errErrorInRow = "%s, in row %d"
+
+// RowError is an error of a columnar evaluation
+// in the row at the index.
+type RowError struct {
+	err Error
+	row int
+}
+
+// Error returns the error message text.
+func (err RowError) Error() string {
+	return err.Localize(English)
+}
+
+// Localize returns the error message text in the language of the localizer.
+func (err RowError) Localize(localizer Localizer) string {
+	return sprintf(localizer, MessageErrorInRow, Localize(err.err, localizer), err.row)
+}
+
+// Unwrap returns the located error of the row.
+func (err RowError) Unwrap() error {
+	return err.err
+}
+
+// Code returns the code of the error of the row.
+func (err RowError) Code() Code {
+	return err.err.Code()
+}
+
+// Span returns the span of the source the error of the row is located at.
+func (err RowError) Span() Span {
+	return err.err.Span()
+}
+
+// Details returns the details of the error of the row and the row.
+func (err RowError) Details() map[string]any {
+	details := map[string]any{"row": err.row}
+
+	var detailer interface{ Details() map[string]any }
+	if errors.As(err.err, &detailer) {
+		maps.Copy(details, detailer.Details())
+	}
+
+	return details
+}
+
+// MarshalJSON encodes the error as JSON.
+func (err RowError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ToJSON(err))
+}
+
+// Row returns the index of the row.
+func (err RowError) Row() int {
+	return err.row
+}
+
+// NewErrRow cerate a new error of the row at the index.
+func NewErrRow(err Error, row int) RowError {
+	return RowError{
+		err: err,
+		row: row,
+	}
+}
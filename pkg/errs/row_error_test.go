@@ -0,0 +1,27 @@
+package errs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowError(t *testing.T) {
+	t.Parallel()
+
+	source := "a / b"
+	located := NewErrorAtSpan(ErrDivisionByZero, NewSpan(source, 2, 3))
+	err := NewErrRow(located, 3)
+
+	require.Equal(t, "division by zero, at line 1, column 3, in row 3", err.Error())
+	require.Equal(t, "Division durch null, in Zeile 1, Spalte 3, in Datenzeile 3", err.Localize(German))
+	require.Equal(t, 3, err.Row())
+	require.Equal(t, located.Span(), err.Span())
+	require.Equal(t, CodeDivisionByZero, CodeOf(err))
+	require.ErrorIs(t, err, ErrDivisionByZero)
+	require.Equal(t, located, err.Unwrap())
+	require.Equal(t, map[string]any{"row": 3}, err.Details())
+
+	unknown := NewErrRow(NewErrorAtSpan(NewErrUnknownIdentifier("x", "y"), NewSpan("x", 0, 1)), 0)
+	require.Equal(t, map[string]any{"row": 0, "name": "x", "suggestions": []string{"y"}}, unknown.Details())
+}
@@ -0,0 +1,47 @@
+package errs
+
+import "unicode/utf8"
+
+// Position in the source of an expression.
+type Position struct {
+	// Offset in bytes, starting at 0.
+	Offset int `json:"offset"`
+	// Line starting at 1.
+	Line int `json:"line"`
+	// Column in runes, starting at 1.
+	Column int `json:"column"`
+}
+
+// Advance returns the position at the given offset by scanning the source
+// from this position. The offset must not be before this position.
+func (pos Position) Advance(source string, offset int) Position {
+	for pos.Offset < offset && pos.Offset < len(source) {
+		char, size := utf8.DecodeRuneInString(source[pos.Offset:])
+		pos.Offset += size
+
+		if char == '\n' {
+			pos.Line++
+			pos.Column = 1
+		} else {
+			pos.Column++
+		}
+	}
+
+	return pos
+}
+
+// Span of the source from Start (inclusive) to End (exclusive).
+type Span struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// NewSpan creates a span for the byte offsets start and end in the source.
+func NewSpan(source string, start, end int) Span {
+	begin := Position{Line: 1, Column: 1}.Advance(source, start)
+
+	return Span{
+		Start: begin,
+		End:   begin.Advance(source, end),
+	}
+}
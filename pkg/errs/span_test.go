@@ -0,0 +1,35 @@
+package errs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSpan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Single_Line", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t,
+			Span{Start: Position{Offset: 2, Line: 1, Column: 3}, End: Position{Offset: 3, Line: 1, Column: 4}},
+			NewSpan("1 / 0", 2, 3),
+		)
+	})
+
+	t.Run("Multi_Line", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t,
+			Span{Start: Position{Offset: 10, Line: 2, Column: 3}, End: Position{Offset: 11, Line: 2, Column: 4}},
+			NewSpan("true &&\n  x", 10, 11),
+		)
+	})
+
+	t.Run("Multi_Byte_Runes", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t,
+			Span{Start: Position{Offset: 9, Line: 1, Column: 8}, End: Position{Offset: 10, Line: 1, Column: 9}},
+			NewSpan("'äö' + x", 9, 10),
+		)
+	})
+}
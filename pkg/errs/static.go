@@ -3,6 +3,16 @@ package errs
 import "errors"
 
 var (
-	ErrEmptyExpression = errors.New("empty expression")
-	ErrDivisionByZero  = errors.New("division by zero")
+	ErrEmptyExpression  = errors.New("empty expression")
+	ErrDivisionByZero   = errors.New("division by zero")
+	ErrIntegerOverflow  = errors.New("integer overflow")
+	ErrNotInteger       = errors.New("operation requires integer operands")
+	ErrNegativeShift    = errors.New("negative shift amount")
+	ErrNumberOutOfRange = errors.New("number out of range")
+	ErrSourceTooLong    = errors.New("source exceeds the maximum length")
+	ErrMaxDepth         = errors.New("nesting exceeds the maximum depth")
+	ErrMaxSteps         = errors.New("evaluation exceeds the maximum steps")
+	ErrStringTooLong    = errors.New("text exceeds the maximum length")
+	ErrMaxCost          = errors.New("estimated cost exceeds the maximum")
+	ErrColumnLength     = errors.New("columns have different lengths")
 )
@@ -0,0 +1,39 @@
+package errs
+
+const errUnexpectedCharacterMessage = "Unexpected character: \"%s\""
+
+// UnexpectedCharacterError is an error
+// type for characters that start no token.
+type UnexpectedCharacterError struct {
+	character string
+}
+
+// Error returns the error message text.
+func (err UnexpectedCharacterError) Error() string {
+	return err.Localize(English)
+}
+
+// Localize returns the error message text in the language of the localizer.
+func (err UnexpectedCharacterError) Localize(localizer Localizer) string {
+	return sprintf(localizer, CodeMessage(CodeUnexpectedCharacter), err.character)
+}
+
+// Character returns the unexpected character.
+func (err UnexpectedCharacterError) Character() string {
+	return err.character
+}
+
+// Code returns CodeUnexpectedCharacter.
+func (err UnexpectedCharacterError) Code() Code {
+	return CodeUnexpectedCharacter
+}
+
+// Details returns the fields of the error.
+func (err UnexpectedCharacterError) Details() map[string]any {
+	return map[string]any{"character": err.character}
+}
+
+// NewErrUnexpectedCharacter cerate a new error.
+func NewErrUnexpectedCharacter(character string) UnexpectedCharacterError {
+	return UnexpectedCharacterError{character: character}
+}
@@ -0,0 +1,21 @@
+package errs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrUnexpectedCharacter(t *testing.T) {
+	t.Parallel()
+
+	key := "#"
+	err := NewErrUnexpectedCharacter(key)
+	require.Equal(t,
+		fmt.Sprintf(errUnexpectedCharacterMessage, key),
+		err.Error(),
+	)
+	require.Equal(t, key, err.Character())
+	require.Equal(t, CodeUnexpectedCharacter, err.Code())
+}
@@ -1,9 +1,5 @@
 package errs
 
-import (
-	"fmt"
-)
-
 const errUnexpectedInputEndMessage = "Unexpected end of input, expected: \"%s\""
 
 // UnexpectedInputEndError is an error
@@ -14,7 +10,27 @@ type UnexpectedInputEndError struct {
 
 // Error returns the error message text.
 func (err UnexpectedInputEndError) Error() string {
-	return fmt.Sprintf(errUnexpectedInputEndMessage, err.tokenType)
+	return err.Localize(English)
+}
+
+// Localize returns the error message text in the language of the localizer.
+func (err UnexpectedInputEndError) Localize(localizer Localizer) string {
+	return sprintf(localizer, CodeMessage(CodeUnexpectedInputEnd), err.tokenType)
+}
+
+// TokenType returns the token type that was expected.
+func (err UnexpectedInputEndError) TokenType() string {
+	return err.tokenType
+}
+
+// Code returns CodeUnexpectedInputEnd.
+func (err UnexpectedInputEndError) Code() Code {
+	return CodeUnexpectedInputEnd
+}
+
+// Details returns the fields of the error.
+func (err UnexpectedInputEndError) Details() map[string]any {
+	return map[string]any{"expected": err.tokenType}
 }
 
 // NewErrUnexpectedInputEnd cerate a new error.
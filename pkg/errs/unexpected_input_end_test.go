@@ -11,8 +11,11 @@ func TestErrUnexpectedInputEnd(t *testing.T) {
 	t.Parallel()
 
 	key := "b"
+	err := NewErrUnexpectedInputEnd(key)
 	require.Equal(t,
 		fmt.Sprintf(errUnexpectedInputEndMessage, key),
-		NewErrUnexpectedInputEnd(key).Error(),
+		err.Error(),
 	)
+	require.Equal(t, key, err.TokenType())
+	require.Equal(t, CodeUnexpectedInputEnd, err.Code())
 }
@@ -1,9 +1,5 @@
 package errs
 
-import (
-	"fmt"
-)
-
 const errUnexpectedTokenTypeMessage = "Unexpected token: \"%s\", expected: \"%s\""
 
 // UnexpectedTokenTypeError.TokenType is an error
@@ -15,8 +11,32 @@ type UnexpectedTokenTypeError struct {
 
 // Error returns the error message text.
 func (err UnexpectedTokenTypeError) Error() string {
-	return fmt.Sprintf(errUnexpectedTokenTypeMessage,
-		err.actual, err.expected)
+	return err.Localize(English)
+}
+
+// Localize returns the error message text in the language of the localizer.
+func (err UnexpectedTokenTypeError) Localize(localizer Localizer) string {
+	return sprintf(localizer, CodeMessage(CodeUnexpectedToken), err.actual, err.expected)
+}
+
+// Actual returns the token type that was found.
+func (err UnexpectedTokenTypeError) Actual() string {
+	return err.actual
+}
+
+// Expected returns the token type that was expected.
+func (err UnexpectedTokenTypeError) Expected() string {
+	return err.expected
+}
+
+// Code returns CodeUnexpectedToken.
+func (err UnexpectedTokenTypeError) Code() Code {
+	return CodeUnexpectedToken
+}
+
+// Details returns the fields of the error.
+func (err UnexpectedTokenTypeError) Details() map[string]any {
+	return map[string]any{"actual": err.actual, "expected": err.expected}
 }
 
 // NewErrUnexpectedTokenType cerate a new error.
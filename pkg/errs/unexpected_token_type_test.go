@@ -12,8 +12,12 @@ func TestErrUnexpectedTokenTypeMessage(t *testing.T) {
 
 	key1 := "abc"
 	key2 := "abc"
+	err := NewErrUnexpectedTokenType(key1, key2)
 	require.Equal(t,
 		fmt.Sprintf(errUnexpectedTokenTypeMessage, key1, key2),
-		NewErrUnexpectedTokenType(key1, key2).Error(),
+		err.Error(),
 	)
+	require.Equal(t, key1, err.Actual())
+	require.Equal(t, key2, err.Expected())
+	require.Equal(t, CodeUnexpectedToken, err.Code())
 }
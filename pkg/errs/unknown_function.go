@@ -0,0 +1,46 @@
+package errs
+
+const errUnknownFunctionMessage = "Unknown function: \"%s\""
+
+// UnknownFunctionError is an error
+// type for calls of unregistered functions.
+type UnknownFunctionError struct {
+	name        string
+	suggestions []string
+}
+
+// Error returns the error message text.
+func (err UnknownFunctionError) Error() string {
+	return err.Localize(English)
+}
+
+// Localize returns the error message text in the language of the localizer.
+func (err UnknownFunctionError) Localize(localizer Localizer) string {
+	return didYouMean(localizer, sprintf(localizer, CodeMessage(CodeUnknownFunction), err.name), err.suggestions)
+}
+
+// Name returns the unknown function name.
+func (err UnknownFunctionError) Name() string {
+	return err.name
+}
+
+// Suggestions returns the registered function names closest to the unknown one.
+func (err UnknownFunctionError) Suggestions() []string {
+	return err.suggestions
+}
+
+// Code returns CodeUnknownFunction.
+func (err UnknownFunctionError) Code() Code {
+	return CodeUnknownFunction
+}
+
+// Details returns the fields of the error.
+func (err UnknownFunctionError) Details() map[string]any {
+	return suggestionDetails(err.name, err.suggestions)
+}
+
+// NewErrUnknownFunction cerate a new error
+// with the closest registered names as suggestions.
+func NewErrUnknownFunction(name string, suggestions ...string) UnknownFunctionError {
+	return UnknownFunctionError{name: name, suggestions: suggestions}
+}
@@ -0,0 +1,22 @@
+package errs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrUnknownFunction(t *testing.T) {
+	t.Parallel()
+
+	key := "lenght"
+	err := NewErrUnknownFunction(key, "length")
+	require.Equal(t,
+		fmt.Sprintf(errDidYouMeanMessage, fmt.Sprintf(errUnknownFunctionMessage, key), `"length"`),
+		err.Error(),
+	)
+	require.Equal(t, key, err.Name())
+	require.Equal(t, []string{"length"}, err.Suggestions())
+	require.Equal(t, CodeUnknownFunction, err.Code())
+}
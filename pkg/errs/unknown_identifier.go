@@ -0,0 +1,67 @@
+package errs
+
+const (
+	errUnknownIdentifierMessage = "Unknown identifier: \"%s\""
+	errDidYouMeanMessage        = "%s, did you mean %s?"
+)
+
+// UnknownIdentifierError is an error
+// type for unknown identifiers.
+type UnknownIdentifierError struct {
+	name        string
+	suggestions []string
+}
+
+// Error returns the error message text.
+func (err UnknownIdentifierError) Error() string {
+	return err.Localize(English)
+}
+
+// Localize returns the error message text in the language of the localizer.
+func (err UnknownIdentifierError) Localize(localizer Localizer) string {
+	return didYouMean(localizer, sprintf(localizer, CodeMessage(CodeUnknownIdentifier), err.name), err.suggestions)
+}
+
+// Name returns the unknown identifier.
+func (err UnknownIdentifierError) Name() string {
+	return err.name
+}
+
+// Suggestions returns the known names closest to the unknown identifier.
+func (err UnknownIdentifierError) Suggestions() []string {
+	return err.suggestions
+}
+
+// Code returns CodeUnknownIdentifier.
+func (err UnknownIdentifierError) Code() Code {
+	return CodeUnknownIdentifier
+}
+
+// Details returns the fields of the error.
+func (err UnknownIdentifierError) Details() map[string]any {
+	return suggestionDetails(err.name, err.suggestions)
+}
+
+// NewErrUnknownIdentifier cerate a new error
+// with the closest known names as suggestions.
+func NewErrUnknownIdentifier(name string, suggestions ...string) UnknownIdentifierError {
+	return UnknownIdentifierError{name: name, suggestions: suggestions}
+}
+
+// didYouMean appends the suggestions to the message.
+func didYouMean(localizer Localizer, message string, suggestions []string) string {
+	if len(suggestions) == 0 {
+		return message
+	}
+
+	return sprintf(localizer, MessageDidYouMean, message, quoteAll(localizer, suggestions))
+}
+
+func suggestionDetails(name string, suggestions []string) map[string]any {
+	details := map[string]any{"name": name}
+	if len(suggestions) > 0 {
+		details["suggestions"] = suggestions
+	}
+
+	return details
+}
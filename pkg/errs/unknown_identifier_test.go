@@ -0,0 +1,36 @@
+package errs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrUnknownIdentifier(t *testing.T) {
+	t.Parallel()
+
+	key := "trueValue"
+	err := NewErrUnknownIdentifier(key)
+	require.Equal(t,
+		fmt.Sprintf(errUnknownIdentifierMessage, key),
+		err.Error(),
+	)
+	require.Equal(t, key, err.Name())
+	require.Empty(t, err.Suggestions())
+	require.Equal(t, CodeUnknownIdentifier, err.Code())
+	require.Equal(t, map[string]any{"name": key}, err.Details())
+}
+
+func TestErrUnknownIdentifier_Suggestions(t *testing.T) {
+	t.Parallel()
+
+	key := "countr"
+	err := NewErrUnknownIdentifier(key, "country", "count")
+	require.Equal(t,
+		fmt.Sprintf(errDidYouMeanMessage, fmt.Sprintf(errUnknownIdentifierMessage, key), `"country" or "count"`),
+		err.Error(),
+	)
+	require.Equal(t, []string{"country", "count"}, err.Suggestions())
+	require.Equal(t, map[string]any{"name": key, "suggestions": []string{"country", "count"}}, err.Details())
+}
@@ -0,0 +1,303 @@
+package expr
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+)
+
+// arithmetic applies the arithmetic or bitwise operator on both values.
+// Integer arithmetic is used if enabled and both values are integers,
+// else the values are converted to float64.
+func arithmetic(cfg *config, operator string, left, right interface{}) (interface{}, error) {
+	if cfg.integers && operator != "/" && isInteger(left) && isInteger(right) {
+		return integerArithmetic(cfg, operator, left, right)
+	}
+
+	return floatArithmetic(operator, left, right)
+}
+
+func floatArithmetic(operator string, left, right interface{}) (interface{}, error) {
+	value, err := floatOperation(operator, convertFloat(left), convertFloat(right))
+	if err != nil {
+		return nil, err
+	}
+
+	return value.boxed(), nil
+}
+
+// floatOperation applies the arithmetic or bitwise operator on float64 values.
+func floatOperation(operator string, left, right float64) (tagged, error) {
+	switch operator {
+	case "+":
+		return floatValue(left + right), nil
+	case "-":
+		return floatValue(left - right), nil
+	case "*":
+		return floatValue(left * right), nil
+	case "/":
+		if right == 0 {
+			return tagged{}, errs.ErrDivisionByZero
+		}
+
+		return floatValue(left / right), nil
+	case "%":
+		divisor := int64(math.Round(right))
+		if divisor == 0 {
+			return tagged{}, errs.ErrDivisionByZero
+		}
+
+		return intValue(int64(math.Round(left)) % divisor), nil
+	case "//":
+		if right == 0 {
+			return tagged{}, errs.ErrDivisionByZero
+		}
+
+		return floatValue(math.Trunc(left / right)), nil
+	case "**":
+		return floatValue(math.Pow(left, right)), nil
+	}
+
+	return floatBitwise(operator, left, right)
+}
+
+// floatBitwise applies the bitwise operator on whole numbers,
+// shifts wrap around like on Go's int64.
+func floatBitwise(operator string, left, right float64) (tagged, error) {
+	leftValue, leftOk := wholeNumber(left)
+	rightValue, rightOk := wholeNumber(right)
+
+	if !leftOk || !rightOk {
+		return tagged{}, errs.ErrNotInteger
+	}
+
+	value, _, err := int64Arithmetic(operator, leftValue, rightValue)
+	if err != nil {
+		return tagged{}, err
+	}
+
+	return floatValue(float64(value)), nil
+}
+
+func wholeNumber(value float64) (int64, bool) {
+	if value != math.Trunc(value) || value < math.MinInt64 || value >= math.MaxInt64 {
+		return 0, false
+	}
+
+	return int64(value), true
+}
+
+func integerArithmetic(cfg *config, operator string, left, right interface{}) (interface{}, error) {
+	leftValue, leftOk := left.(int64)
+	rightValue, rightOk := right.(int64)
+
+	if leftOk && rightOk {
+		if operator == "**" && rightValue < 0 {
+			return floatArithmetic(operator, left, right)
+		}
+
+		value, ok, err := int64Arithmetic(operator, leftValue, rightValue)
+		if err != nil || ok {
+			return value, err
+		}
+
+		if cfg.overflow == OverflowError {
+			return nil, errs.ErrIntegerOverflow
+		}
+	}
+
+	return bigArithmetic(cfg, operator, convertBigInt(left), convertBigInt(right))
+}
+
+// int64Arithmetic returns false if the operation overflows.
+func int64Arithmetic(operator string, left, right int64) (int64, bool, error) {
+	switch operator {
+	case "+":
+		value := left + right
+
+		return value, (value > left) == (right > 0), nil
+	case "-":
+		value := left - right
+
+		return value, (value < left) == (right > 0), nil
+	case "*":
+		value, ok := mulInt64(left, right)
+
+		return value, ok, nil
+	case "//":
+		if right == 0 {
+			return 0, false, errs.ErrDivisionByZero
+		}
+
+		return left / right, left != math.MinInt64 || right != -1, nil
+	case "%":
+		if right == 0 {
+			return 0, false, errs.ErrDivisionByZero
+		}
+
+		return left % right, true, nil
+	case "**":
+		value, ok := powInt64(left, right)
+
+		return value, ok, nil
+	case "&":
+		return left & right, true, nil
+	case "|":
+		return left | right, true, nil
+	case "^":
+		return left ^ right, true, nil
+	case "&^":
+		return left &^ right, true, nil
+	case "<<":
+		if right < 0 {
+			return 0, false, errs.ErrNegativeShift
+		}
+
+		value := left << right
+
+		return value, value>>right == left, nil
+	case ">>":
+		if right < 0 {
+			return 0, false, errs.ErrNegativeShift
+		}
+
+		return left >> right, true, nil
+	}
+
+	return 0, false, errs.NewErrUnexpectedTokenType(operator, "arithmetic operation")
+}
+
+func mulInt64(left, right int64) (int64, bool) {
+	if left == 0 || right == 0 {
+		return 0, true
+	}
+
+	if (left == -1 && right == math.MinInt64) || (right == -1 && left == math.MinInt64) {
+		return 0, false
+	}
+
+	value := left * right
+
+	return value, value/right == left
+}
+
+func powInt64(base, exponent int64) (int64, bool) {
+	result := int64(1)
+
+	for exponent > 0 {
+		var ok bool
+
+		if exponent&1 == 1 {
+			if result, ok = mulInt64(result, base); !ok {
+				return 0, false
+			}
+		}
+
+		exponent >>= 1
+		if exponent > 0 {
+			if base, ok = mulInt64(base, base); !ok {
+				return 0, false
+			}
+		}
+	}
+
+	return result, true
+}
+
+// bigArithmetic reports results larger than the maximum integer bits
+// as overflow before they are computed and exhaust the memory.
+func bigArithmetic(cfg *config, operator string, left, right *big.Int) (interface{}, error) {
+	value := new(big.Int)
+
+	switch operator {
+	case "+":
+		value.Add(left, right)
+	case "-":
+		value.Sub(left, right)
+	case "*":
+		if !cfg.fitsIntegerBits(left.BitLen() + right.BitLen()) {
+			return nil, errs.ErrIntegerOverflow
+		}
+
+		value.Mul(left, right)
+	case "//":
+		if right.Sign() == 0 {
+			return nil, errs.ErrDivisionByZero
+		}
+
+		value.Quo(left, right)
+	case "%":
+		if right.Sign() == 0 {
+			return nil, errs.ErrDivisionByZero
+		}
+
+		value.Rem(left, right)
+	case "&":
+		value.And(left, right)
+	case "|":
+		value.Or(left, right)
+	case "^":
+		value.Xor(left, right)
+	case "&^":
+		value.AndNot(left, right)
+	case "<<", ">>":
+		return bigShift(cfg, operator, left, right)
+	case "**":
+		if right.Sign() < 0 {
+			return floatArithmetic(operator, left, right)
+		}
+
+		if !powFits(cfg, left, right) {
+			return nil, errs.ErrIntegerOverflow
+		}
+
+		value.Exp(left, right, nil)
+	default:
+		return nil, errs.NewErrUnexpectedTokenType(operator, "arithmetic operation")
+	}
+
+	return normalizeBigInt(value), nil
+}
+
+func bigShift(cfg *config, operator string, left, right *big.Int) (interface{}, error) {
+	if right.Sign() < 0 {
+		return nil, errs.ErrNegativeShift
+	}
+
+	if !right.IsUint64() {
+		return nil, errs.ErrIntegerOverflow
+	}
+
+	if operator == "<<" {
+		shift := right.Uint64()
+		if left.Sign() != 0 && cfg.maxIntegerBits > 0 &&
+			(shift > uint64(cfg.maxIntegerBits) || !cfg.fitsIntegerBits(left.BitLen()+int(shift))) {
+			return nil, errs.ErrIntegerOverflow
+		}
+
+		return normalizeBigInt(new(big.Int).Lsh(left, uint(shift))), nil
+	}
+
+	return normalizeBigInt(new(big.Int).Rsh(left, uint(right.Uint64()))), nil
+}
+
+// powFits estimates the size of the power as bits of the base times the exponent.
+// Powers of 0, 1 and -1 stay small with any exponent.
+func powFits(cfg *config, base, exponent *big.Int) bool {
+	bits := base.BitLen()
+	if bits <= 1 || cfg.maxIntegerBits <= 0 {
+		return true
+	}
+
+	return exponent.IsInt64() && exponent.Int64() <= int64(cfg.maxIntegerBits/bits)
+}
+
+// normalizeBigInt demotes the value to int64 if it fits.
+func normalizeBigInt(value *big.Int) interface{} {
+	if value.IsInt64() {
+		return value.Int64()
+	}
+
+	return value
+}
@@ -0,0 +1,83 @@
+package expr
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Arithmetic(t *testing.T) {
+	t.Parallel()
+
+	maxPlusOne, _ := new(big.Int).SetString("9223372036854775808", 10)
+
+	tcs := []struct {
+		name     string
+		config   *config
+		operator string
+		left     interface{}
+		right    interface{}
+		expect   interface{}
+		err      error
+	}{
+		{name: "Float_Add", config: newConfig(), operator: "+", left: 1.5, right: 1.0, expect: 2.5},
+		{name: "Float_Pow", config: newConfig(), operator: "**", left: 2.0, right: 3.0, expect: 8.0},
+		{name: "Float_Division_By_Zero", config: newConfig(), operator: "/", left: 1.0, right: 0.0, err: errs.ErrDivisionByZero},
+		{name: "Float_Modulo_Rounded_Zero", config: newConfig(), operator: "%", left: 1.0, right: 0.4, err: errs.ErrDivisionByZero},
+		{name: "Float_Integer_Division", config: newConfig(), operator: "//", left: 7.0, right: 2.0, expect: 3.0},
+		{name: "Float_Bitwise", config: newConfig(), operator: "&^", left: 7.0, right: 2.0, expect: 5.0},
+		{name: "Float_Bitwise_Fraction", config: newConfig(), operator: "|", left: 1.5, right: 2.0, err: errs.ErrNotInteger},
+		{name: "Int_Add", config: newConfig(WithIntegers(OverflowError)), operator: "+", left: int64(1), right: int64(2), expect: int64(3)},
+		{name: "Int_Division_Is_Float", config: newConfig(WithIntegers(OverflowError)), operator: "/", left: int64(3), right: int64(2), expect: 1.5},
+		{name: "Int_Mixed_Is_Float", config: newConfig(WithIntegers(OverflowError)), operator: "*", left: int64(3), right: 0.5, expect: 1.5},
+		{name: "Int_Pow", config: newConfig(WithIntegers(OverflowError)), operator: "**", left: int64(3), right: int64(4), expect: int64(81)},
+		{name: "Int_Pow_Negative", config: newConfig(WithIntegers(OverflowError)), operator: "**", left: int64(2), right: int64(-1), expect: 0.5},
+		{name: "Int_Modulo_By_Zero", config: newConfig(WithIntegers(OverflowError)), operator: "%", left: int64(3), right: int64(0), err: errs.ErrDivisionByZero},
+		{name: "Int_Add_Overflow", config: newConfig(WithIntegers(OverflowError)), operator: "+", left: int64(math.MaxInt64), right: int64(1), err: errs.ErrIntegerOverflow},
+		{name: "Int_Sub_Overflow", config: newConfig(WithIntegers(OverflowError)), operator: "-", left: int64(math.MinInt64), right: int64(1), err: errs.ErrIntegerOverflow},
+		{name: "Int_Mul_Overflow", config: newConfig(WithIntegers(OverflowError)), operator: "*", left: int64(math.MinInt64), right: int64(-1), err: errs.ErrIntegerOverflow},
+		{name: "Int_Pow_Overflow", config: newConfig(WithIntegers(OverflowError)), operator: "**", left: int64(2), right: int64(63), err: errs.ErrIntegerOverflow},
+		{name: "Int_Integer_Division", config: newConfig(WithIntegers(OverflowError)), operator: "//", left: int64(-7), right: int64(2), expect: int64(-3)},
+		{name: "Int_Integer_Division_Overflow", config: newConfig(WithIntegers(OverflowError)), operator: "//", left: int64(math.MinInt64), right: int64(-1), err: errs.ErrIntegerOverflow},
+		{name: "Int_Xor", config: newConfig(WithIntegers(OverflowError)), operator: "^", left: int64(6), right: int64(3), expect: int64(5)},
+		{name: "Int_Shift_Right", config: newConfig(WithIntegers(OverflowError)), operator: ">>", left: int64(-8), right: int64(1), expect: int64(-4)},
+		{name: "Int_Shift_Left_Overflow", config: newConfig(WithIntegers(OverflowError)), operator: "<<", left: int64(1), right: int64(63), err: errs.ErrIntegerOverflow},
+		{name: "Int_Negative_Shift", config: newConfig(WithIntegers(OverflowError)), operator: "<<", left: int64(1), right: int64(-1), err: errs.ErrNegativeShift},
+		{name: "Int_Shift_Left_Promote", config: newConfig(WithIntegers(OverflowPromote)), operator: "<<", left: int64(1), right: int64(63), expect: maxPlusOne},
+		{name: "Big_Shift_Right", config: newConfig(WithIntegers(OverflowPromote)), operator: ">>", left: maxPlusOne, right: int64(62), expect: int64(2)},
+		{name: "Big_And", config: newConfig(WithIntegers(OverflowPromote)), operator: "&", left: maxPlusOne, right: int64(-1), expect: maxPlusOne},
+		{name: "Int_Add_Promote", config: newConfig(WithIntegers(OverflowPromote)), operator: "+", left: int64(math.MaxInt64), right: int64(1), expect: maxPlusOne},
+		{name: "Int_Pow_Promote", config: newConfig(WithIntegers(OverflowPromote)), operator: "**", left: int64(2), right: int64(63), expect: maxPlusOne},
+		{name: "Big_Demote", config: newConfig(WithIntegers(OverflowPromote)), operator: "-", left: maxPlusOne, right: int64(1), expect: int64(math.MaxInt64)},
+		{name: "Big_Modulo", config: newConfig(WithIntegers(OverflowPromote)), operator: "%", left: maxPlusOne, right: int64(10), expect: int64(8)},
+		{name: "Big_Modulo_By_Zero", config: newConfig(WithIntegers(OverflowPromote)), operator: "%", left: maxPlusOne, right: int64(0), err: errs.ErrDivisionByZero},
+		{name: "Big_Pow_Too_Large", config: newConfig(WithIntegers(OverflowPromote)), operator: "**", left: int64(2), right: int64(40_000_000_000), err: errs.ErrIntegerOverflow},
+		{name: "Big_Pow_Big_Exponent", config: newConfig(WithIntegers(OverflowPromote)), operator: "**", left: maxPlusOne, right: maxPlusOne, err: errs.ErrIntegerOverflow},
+		{name: "Big_Pow_Base_One", config: newConfig(WithIntegers(OverflowPromote)), operator: "**", left: big.NewInt(1), right: maxPlusOne, expect: int64(1)},
+		{name: "Big_Shift_Left_Too_Large", config: newConfig(WithIntegers(OverflowPromote)), operator: "<<", left: int64(1), right: int64(40_000_000_000), err: errs.ErrIntegerOverflow},
+		{name: "Big_Shift_Left_Zero", config: newConfig(WithIntegers(OverflowPromote)), operator: "<<", left: int64(0), right: int64(40_000_000_000), expect: int64(0)},
+		{name: "Big_Shift_Right_Large", config: newConfig(WithIntegers(OverflowPromote)), operator: ">>", left: maxPlusOne, right: int64(40_000_000_000), expect: int64(0)},
+		{name: "Big_Mul_Too_Large", config: newConfig(WithIntegers(OverflowPromote)), operator: "*", left: new(big.Int).Lsh(big.NewInt(1), DefaultMaxIntegerBits-1), right: maxPlusOne, err: errs.ErrIntegerOverflow},
+	}
+
+	for _, tc := range tcs {
+		tcRef := tc
+
+		t.Run(tcRef.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := arithmetic(tcRef.config, tcRef.operator, tcRef.left, tcRef.right)
+			if tcRef.err != nil {
+				assert.ErrorIs(t, err, tcRef.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tcRef.expect, actual)
+		})
+	}
+}
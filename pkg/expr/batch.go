@@ -0,0 +1,204 @@
+package expr
+
+import (
+	"context"
+	"iter"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// batchChunkSize is the number of environments a worker of EvalBatch takes at once.
+const batchChunkSize = 64
+
+// BatchOption configures EvalBatch and EvalSeq.
+type BatchOption func(*batchConfig)
+
+type batchConfig struct {
+	ctx         context.Context
+	parallelism int
+}
+
+func newBatchConfig(opts ...BatchOption) *batchConfig {
+	cfg := &batchConfig{ctx: context.Background(), parallelism: 1}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
+
+// WithParallelism evaluates the environments by the given number of goroutines,
+// zero or less uses runtime.GOMAXPROCS. By default they are evaluated sequentially.
+func WithParallelism(workers int) BatchOption {
+	return func(c *batchConfig) {
+		if workers <= 0 {
+			workers = runtime.GOMAXPROCS(0)
+		}
+
+		c.parallelism = workers
+	}
+}
+
+// WithBatchContext evaluates with the context like Program.EvalContext,
+// once it is done the remaining results are its error.
+func WithBatchContext(ctx context.Context) BatchOption {
+	return func(c *batchConfig) {
+		c.ctx = ctx
+	}
+}
+
+// EvalBatch evaluates the program with every environment and returns the
+// results in the same order. The state of the evaluation is reused between
+// the environments of a goroutine, see Program.Eval for nil environments.
+func (p *Program) EvalBatch(envs []Env, opts ...BatchOption) []Result {
+	cfg := newBatchConfig(opts...)
+	results := make([]Result, len(envs))
+	workers := min(cfg.parallelism, (len(envs)+batchChunkSize-1)/batchChunkSize)
+
+	if workers <= 1 {
+		e := &evaluation{ctx: cfg.ctx, config: p.config}
+		for i, env := range envs {
+			results[i] = p.eval(e, env)
+		}
+
+		return results
+	}
+
+	var (
+		wg   sync.WaitGroup
+		next atomic.Int64
+	)
+
+	for range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			e := &evaluation{ctx: cfg.ctx, config: p.config}
+
+			for {
+				start := int(next.Add(batchChunkSize)) - batchChunkSize
+				if start >= len(envs) {
+					return
+				}
+
+				for i := start; i < min(start+batchChunkSize, len(envs)); i++ {
+					results[i] = p.eval(e, envs[i])
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// EvalSeq evaluates the program with every environment of the sequence and
+// yields the results in the same order, so environments can be streamed
+// without holding all of them. With parallelism the sequence is consumed
+// by another goroutine and up to twice the number of workers environments
+// are evaluated ahead. Stopping the iteration waits for these evaluations,
+// a panic of the sequence is raised again in the goroutine of the loop.
+func (p *Program) EvalSeq(envs iter.Seq[Env], opts ...BatchOption) iter.Seq[Result] {
+	cfg := newBatchConfig(opts...)
+
+	if cfg.parallelism <= 1 {
+		return func(yield func(Result) bool) {
+			e := &evaluation{ctx: cfg.ctx, config: p.config}
+
+			for env := range envs {
+				if !yield(p.eval(e, env)) {
+					return
+				}
+			}
+		}
+	}
+
+	return func(yield func(Result) bool) {
+		p.evalParallel(cfg, envs, yield)
+	}
+}
+
+type batchJob struct {
+	env    Env
+	result chan Result
+}
+
+// evalParallel distributes the environments to the workers and queues
+// the jobs in input order, so the results are yielded in order.
+func (p *Program) evalParallel(cfg *batchConfig, envs iter.Seq[Env], yield func(Result) bool) {
+	var (
+		wg       sync.WaitGroup
+		jobs     = make(chan batchJob)
+		ordered  = make(chan batchJob, 2*cfg.parallelism)
+		done     = make(chan struct{})
+		panicked interface{}
+	)
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		defer close(ordered)
+		defer close(jobs)
+		defer func() {
+			panicked = recover()
+		}()
+
+		for env := range envs {
+			job := batchJob{env: env, result: make(chan Result, 1)}
+
+			// the draining consumer frees the queue, so stopping is checked first
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			select {
+			case ordered <- job:
+			case <-done:
+				return
+			}
+
+			jobs <- job
+		}
+	}()
+
+	for range cfg.parallelism {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			e := &evaluation{ctx: cfg.ctx, config: p.config}
+			for job := range jobs {
+				job.result <- p.eval(e, job.env)
+			}
+		}()
+	}
+
+	// stopping, also by a panic of the loop body, drains the queued jobs,
+	// so the producer is not blocked
+	defer func() {
+		close(done)
+
+		for range ordered {
+		}
+
+		wg.Wait()
+
+		if panicked != nil {
+			panic(panicked)
+		}
+	}()
+
+	for job := range ordered {
+		if !yield(<-job.result) {
+			return
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package expr
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batchEnvs(count int) []Env {
+	envs := make([]Env, count)
+	for i := range envs {
+		envs[i] = Env{"id": i, "name": "n"}
+	}
+
+	return envs
+}
+
+func Test_Program_EvalBatch(t *testing.T) {
+	t.Parallel()
+
+	envs := batchEnvs(1000)
+	envs[7] = Env{"name": "n"}
+	envs[500] = nil
+
+	program, err := Compile("id * 2 + length(name)", WithFunction("length", length), WithEnv(Env{"id": -1, "name": ""}))
+	require.NoError(t, err)
+
+	for _, parallelism := range []int{1, 4, 0} {
+		results := program.EvalBatch(envs, WithParallelism(parallelism))
+		require.Len(t, results, len(envs))
+
+		for i, result := range results {
+			switch i {
+			case 7:
+				assert.Equal(t, errorAt(program.Source(), 0, 2, errs.NewErrUnknownIdentifier("id")), result.Error)
+			case 500:
+				assert.Equal(t, Result{Value: -2.0}, result)
+			default:
+				assert.Equal(t, Result{Value: float64(i*2 + 1)}, result)
+			}
+		}
+	}
+
+	assert.Empty(t, program.EvalBatch(nil, WithParallelism(4)))
+}
+
+func Test_Program_EvalBatch_Context(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	program, err := Compile("id + 1")
+	require.NoError(t, err)
+
+	for _, result := range program.EvalBatch(batchEnvs(200), WithBatchContext(ctx), WithParallelism(2)) {
+		assert.ErrorIs(t, result.Error, context.Canceled)
+	}
+}
+
+func Test_Program_EvalSeq(t *testing.T) {
+	t.Parallel()
+
+	program, err := Compile("id + 1", WithBytecode())
+	require.NoError(t, err)
+
+	for _, parallelism := range []int{1, 3} {
+		values := []interface{}{}
+		for result := range program.EvalSeq(slices.Values(batchEnvs(500)), WithParallelism(parallelism)) {
+			require.NoError(t, result.Error)
+			values = append(values, result.Value)
+		}
+
+		require.Len(t, values, 500)
+
+		for i, value := range values {
+			assert.Equal(t, float64(i+1), value)
+		}
+	}
+}
+
+func Test_Program_EvalSeq_Stop(t *testing.T) {
+	t.Parallel()
+
+	program, err := Compile("id")
+	require.NoError(t, err)
+
+	consumed := 0
+
+	// an endless sequence is only consumed ahead up to the queue
+	endless := func(yield func(Env) bool) {
+		for i := 0; ; i++ {
+			consumed = i
+
+			if !yield(Env{"id": i}) {
+				return
+			}
+		}
+	}
+
+	for _, parallelism := range []int{1, 4} {
+		count := 0
+		for result := range program.EvalSeq(endless, WithParallelism(parallelism)) {
+			assert.Equal(t, float64(count), result.Value)
+
+			if count++; count == 10 {
+				break
+			}
+		}
+
+		assert.Less(t, consumed, 10+2*parallelism+2)
+	}
+}
+
+func Test_Program_EvalSeq_Panic(t *testing.T) {
+	t.Parallel()
+
+	program, err := Compile("id")
+	require.NoError(t, err)
+
+	assert.PanicsWithValue(t, "body", func() {
+		for range program.EvalSeq(slices.Values(batchEnvs(1000)), WithParallelism(4)) {
+			panic("body")
+		}
+	})
+
+	broken := func(yield func(Env) bool) {
+		for i := range 20 {
+			if !yield(Env{"id": i}) {
+				return
+			}
+		}
+
+		panic("sequence")
+	}
+
+	count := 0
+
+	assert.PanicsWithValue(t, "sequence", func() {
+		for range program.EvalSeq(broken, WithParallelism(4)) {
+			count++
+		}
+	})
+	assert.LessOrEqual(t, count, 20)
+}
+
+func BenchmarkProgram_Eval_Loop(b *testing.B) {
+	program, envs := benchmarkBatch(b)
+
+	for i := 0; i < b.N; i++ {
+		results := make([]Result, len(envs))
+		for j, env := range envs {
+			results[j] = program.Eval(env)
+		}
+	}
+}
+
+func BenchmarkProgram_EvalBatch(b *testing.B) {
+	program, envs := benchmarkBatch(b)
+
+	for i := 0; i < b.N; i++ {
+		program.EvalBatch(envs)
+	}
+}
+
+func BenchmarkProgram_EvalBatch_Parallel(b *testing.B) {
+	program, envs := benchmarkBatch(b)
+
+	for i := 0; i < b.N; i++ {
+		program.EvalBatch(envs, WithParallelism(0))
+	}
+}
+
+func benchmarkBatch(b *testing.B) (*Program, []Env) {
+	b.Helper()
+
+	program, err := Compile("id % 3 == 0 && name != 'skip'", WithBytecode())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	envs := batchEnvs(10_000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	return program, envs
+}
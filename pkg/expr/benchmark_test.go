@@ -0,0 +1,86 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// workload is an expression of the benchmark suite with its environment.
+type workload struct {
+	name       string
+	expression string
+	env        Env
+}
+
+// suiteWorkloads are representative expressions, from small formulas
+// to long generated rules, to compare changes with the baseline.
+func suiteWorkloads() []workload {
+	var large, logical, rules, lookups []string
+
+	nesting := strings.Repeat("(", 200) + "x" + strings.Repeat(" + 1)", 200)
+	env := Env{"x": 3, "y": 2.5, "country": "DE", "city": "Berlin", "name": "Alice"}
+	variables := Env{}
+
+	for i := range 1000 {
+		variables[fmt.Sprintf("v%d", i)] = i
+	}
+
+	for i := range 100 {
+		large = append(large, fmt.Sprintf("x * %d - y / %d", i, i+1))
+		logical = append(logical, fmt.Sprintf("x + %d > y && y < %d", i, i+3))
+		lookups = append(lookups, fmt.Sprintf("v%d", i*10))
+	}
+
+	for i := range 20 {
+		rules = append(rules, fmt.Sprintf(
+			"(city == 'Paris%d' || length(name) >= 5 && country == 'DE' && name != `Bob%d`)", i, i))
+	}
+
+	return []workload{
+		{name: "SmallArithmetic", expression: "(x + 2) * 3 - y / 4", env: env},
+		{name: "LargeArithmetic", expression: strings.Join(large, " + "), env: env},
+		{name: "DeepNesting", expression: nesting, env: env},
+		{name: "LogicalChain", expression: strings.Join(logical, " && "), env: env},
+		{name: "StringRules", expression: strings.Join(rules, " && "), env: env},
+		{name: "VariableLookup", expression: strings.Join(lookups, " + "), env: variables},
+	}
+}
+
+// BenchmarkSuite is compared with the baseline by `make bench`,
+// the baseline is updated by `make bench_baseline`.
+func BenchmarkSuite(b *testing.B) {
+	for _, w := range suiteWorkloads() {
+		opts := []Option{WithFunction("length", length)}
+
+		b.Run(w.name+"/Compile", func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := Compile(w.expression, opts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		for _, backend := range backends {
+			b.Run(w.name+"/"+backend.name, func(b *testing.B) {
+				program, err := Compile(w.expression, append(opts, backend.opts...)...)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				if result := program.Eval(w.env); result.Error != nil {
+					b.Fatal(result.Error)
+				}
+
+				b.ReportAllocs()
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					program.Eval(w.env)
+				}
+			})
+		}
+	}
+}
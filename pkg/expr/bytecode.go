@@ -0,0 +1,200 @@
+package expr
+
+// opcode of an instruction of the virtual machine.
+type opcode uint8
+
+const (
+	// opEnter checks the context and counts the step of a node, before its operands are evaluated.
+	opEnter opcode = iota
+	// opConstant pushes the constant of the operand.
+	opConstant
+	// opVariable pushes the variable of the node.
+	opVariable
+	// opCall pops the operand count of arguments and pushes the result of the function.
+	opCall
+	// opNot replaces the top with its negation.
+	opNot
+	// opBool replaces the top with its boolean value.
+	opBool
+	// opJumpIfFalse replaces the top with false and jumps to the operand if it is false, else pops it.
+	opJumpIfFalse
+	// opJumpIfTrue replaces the top with true and jumps to the operand if it is true, else pops it.
+	opJumpIfTrue
+	// opAdd and the following operations pop two values and push the result,
+	// they have a fast path for float64 and int64 operands.
+	opAdd
+	opSubtract
+	opMultiply
+	opDivide
+	// opArithmetic applies any other arithmetic or bitwise operator of the node.
+	opArithmetic
+	opEqual
+	opNotEqual
+	opLess
+	opLessEqual
+	opGreater
+	opGreaterEqual
+	// opFloatAdd and the following operations are the operations above on operands,
+	// that are known to be float64 when compiling, so they skip the type checks.
+	opFloatAdd
+	opFloatSubtract
+	opFloatMultiply
+	opFloatDivide
+	// opArithmetic has no float64 operation
+	_
+	opFloatEqual
+	opFloatNotEqual
+	opFloatLess
+	opFloatLessEqual
+	opFloatGreater
+	opFloatGreaterEqual
+)
+
+// floatOffset is the distance of the float64 operations to the operations.
+const floatOffset = opFloatAdd - opAdd
+
+// binaryOpcode returns the specialized opcode of the operator, or opArithmetic.
+func binaryOpcode(operator string) opcode {
+	switch operator {
+	case "+":
+		return opAdd
+	case "-":
+		return opSubtract
+	case "*":
+		return opMultiply
+	case "/":
+		return opDivide
+	case "==":
+		return opEqual
+	case "!=":
+		return opNotEqual
+	case "<":
+		return opLess
+	case "<=":
+		return opLessEqual
+	case ">":
+		return opGreater
+	case ">=":
+		return opGreaterEqual
+	}
+
+	return opArithmetic
+}
+
+type instruction struct {
+	op opcode
+	// operand is the index of the constant, the count of arguments or the target of a jump.
+	operand int
+}
+
+// bytecode is the compiled tree for the virtual machine.
+type bytecode struct {
+	code []instruction
+	// nodes are the nodes the instructions are compiled from, to locate errors.
+	nodes     []*Node
+	constants []tagged
+	// stackSize is the maximum size of the stack.
+	stackSize int
+}
+
+// bytecodeCompiler compiles the tree in post order, the steps are entered
+// in pre order like by the tree walking evaluation, so the results are identical.
+type bytecodeCompiler struct {
+	bytecode
+	depth    int
+	integers bool
+}
+
+func compileBytecode(root *Node, integers bool) *bytecode {
+	c := &bytecodeCompiler{integers: integers}
+	c.compile(root)
+
+	return &c.bytecode
+}
+
+// emit appends the instruction and tracks the size of the stack by its effect on it.
+func (c *bytecodeCompiler) emit(n *Node, op opcode, operand, effect int) int {
+	c.code = append(c.code, instruction{op: op, operand: operand})
+	c.nodes = append(c.nodes, n)
+
+	c.depth += effect
+	if c.depth > c.stackSize {
+		c.stackSize = c.depth
+	}
+
+	return len(c.code) - 1
+}
+
+func (c *bytecodeCompiler) compile(n *Node) {
+	if n.Kind == LiteralNode {
+		c.constants = append(c.constants, valueOf(n.Value))
+		c.emit(n, opConstant, len(c.constants)-1, 1)
+
+		return
+	}
+
+	c.emit(n, opEnter, 0, 0)
+
+	switch n.Kind {
+	case IdentifierNode:
+		c.emit(n, opVariable, 0, 1)
+	case CallNode:
+		for _, arg := range n.Args {
+			c.compile(arg)
+		}
+
+		c.emit(n, opCall, len(n.Args), 1-len(n.Args))
+	case UnaryNode:
+		c.compile(n.Left)
+		c.emit(n, opNot, 0, 0)
+	default:
+		c.binary(n)
+	}
+}
+
+func (c *bytecodeCompiler) binary(n *Node) {
+	c.compile(n.Left)
+
+	if operators[n.Operator].tokenType == logicalOperationType {
+		op := opJumpIfFalse
+		if n.Operator == "||" {
+			op = opJumpIfTrue
+		}
+
+		// the left value is popped if the right side is evaluated
+		jump := c.emit(n, op, 0, -1)
+		c.compile(n.Right)
+		c.emit(n, opBool, 0, 0)
+		c.code[jump].operand = len(c.code)
+
+		return
+	}
+
+	c.compile(n.Right)
+
+	op := binaryOpcode(n.Operator)
+	if op != opArithmetic && c.isFloat(n.Left) && c.isFloat(n.Right) {
+		op += floatOffset
+	}
+
+	c.emit(n, op, 0, -1)
+}
+
+// isFloat reports if the node always evaluates to a float64, unless it fails.
+// Without integers, numbers are float64 and arithmetic results in float64,
+// only `%` results in an int64.
+func (c *bytecodeCompiler) isFloat(n *Node) bool {
+	switch n.Kind {
+	case LiteralNode:
+		_, ok := n.Value.(float64)
+
+		return ok
+	case BinaryNode:
+		switch n.Operator {
+		case "+", "-", "*", "/", "//", "**":
+			return !c.integers
+		}
+	}
+
+	return false
+}
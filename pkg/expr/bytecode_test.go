@@ -0,0 +1,201 @@
+package expr
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Bytecode_Compile(t *testing.T) {
+	t.Parallel()
+
+	root, err := Parse("a > 1 && !f(2)", WithFunction("f", length))
+	require.NoError(t, err)
+
+	b := compileBytecode(root, false)
+	assert.Equal(t, []instruction{
+		{op: opEnter},
+		{op: opEnter},
+		{op: opEnter},
+		{op: opVariable},
+		{op: opConstant, operand: 0},
+		{op: opGreater},
+		{op: opJumpIfFalse, operand: 13},
+		{op: opEnter},
+		{op: opEnter},
+		{op: opConstant, operand: 1},
+		{op: opCall, operand: 1},
+		{op: opNot},
+		{op: opBool},
+	}, b.code)
+	assert.Equal(t, []tagged{floatValue(1), floatValue(2)}, b.constants)
+	assert.Equal(t, 2, b.stackSize)
+	assert.Len(t, b.nodes, len(b.code))
+}
+
+func Test_Bytecode_Float_Typed(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name       string
+		expression string
+		integers   bool
+		ops        []opcode
+	}{
+		{name: "Literals", expression: "1 + 2 > 3", ops: []opcode{opFloatAdd, opFloatGreater}},
+		{name: "Variables", expression: "(a + 1) * (b - 2) / 3", ops: []opcode{opAdd, opSubtract, opFloatMultiply, opFloatDivide}},
+		{name: "Text", expression: "'a' + 1 == 2", ops: []opcode{opAdd, opFloatEqual}},
+		{name: "Modulo", expression: "a % 2 < 1 + 1", ops: []opcode{opArithmetic, opFloatAdd, opLess}},
+		{name: "Logical", expression: "(a > 1) == (1 < 2)", ops: []opcode{opGreater, opFloatLess, opEqual}},
+		{name: "Integers", expression: "1.5 + 2 > 3.5 * 1.5", integers: true, ops: []opcode{opAdd, opFloatMultiply, opGreater}},
+	}
+
+	for _, tc := range tcs {
+		tcRef := tc
+
+		t.Run(tcRef.name, func(t *testing.T) {
+			t.Parallel()
+
+			var opts []Option
+			if tcRef.integers {
+				opts = append(opts, WithIntegers(OverflowError))
+			}
+
+			root, err := Parse(tcRef.expression, opts...)
+			require.NoError(t, err)
+
+			ops := []opcode{}
+
+			for _, instruction := range compileBytecode(root, tcRef.integers).code {
+				if instruction.op >= opAdd {
+					ops = append(ops, instruction.op)
+				}
+			}
+
+			assert.Equal(t, tcRef.ops, ops)
+		})
+	}
+}
+
+func Test_Bytecode_Large_Stack(t *testing.T) {
+	t.Parallel()
+
+	expression := strings.Repeat("1 + (", 20) + "1" + strings.Repeat(")", 20)
+	program, err := Compile(expression, WithBytecode())
+	require.NoError(t, err)
+
+	assert.Equal(t, 21, program.code.stackSize)
+	assert.Equal(t, 21.0, program.Eval(nil).Value)
+}
+
+func Test_Bytecode_Like_Tree(t *testing.T) {
+	t.Parallel()
+
+	explode := func(_ context.Context, args ...interface{}) (interface{}, error) {
+		return args[1], nil
+	}
+	resolver := func(_ context.Context, name string) (interface{}, bool) {
+		return len(name), name != "unknown"
+	}
+	env := Env{"a": 3, "b": "text", "big": 9223372036854775807}
+
+	tcs := []struct {
+		name       string
+		expression string
+		opts       []Option
+	}{
+		{name: "Mixed_Types", expression: "a + b * 2 - (b == 'text') / 2"},
+		{name: "Arguments_Order", expression: "concat(b, 'x', b) == 'textxtext'"},
+		{name: "Short_Circuit_Or", expression: "a > 1 || explode(1)"},
+		{name: "Short_Circuit_Value", expression: "(0 || 'true') + (1 && 0)"},
+		{name: "Integer_Overflow", expression: "big + 1", opts: []Option{WithIntegers(OverflowError)}},
+		{name: "Integer_Promote", expression: "big * 2 > big - 1", opts: []Option{WithIntegers(OverflowPromote)}},
+		{name: "Integer_Mixed", expression: "a * 1.5 + 7 // 2", opts: []Option{WithIntegers(OverflowError)}},
+		{name: "Resolver", expression: "lazy + other", opts: []Option{WithResolver(resolver)}},
+		{name: "Resolver_Unknown", expression: "lazy + unknown", opts: []Option{WithResolver(resolver)}},
+		{name: "Panic", expression: "1 + explode(1)"},
+		{name: "Division_By_Zero", expression: "a / (a - 3)"},
+		{name: "Steps", expression: "a + (b && concat(b)) + 1", opts: []Option{WithMaxSteps(4)}},
+		{name: "Float_Typed", expression: "(a + 1) * (b - 2) ** 2 >= 2 * 3 // 2"},
+		{name: "Float_Typed_Division_By_Zero", expression: "(a + 1) * 2 / (1 - 1)"},
+		{name: "Float_Typed_Modulo", expression: "a % 2 + 1.5 > (a - 1) * 2"},
+		{name: "Float_Typed_NaN", expression: "0 / 1 * NaN != NaN + 0"},
+	}
+
+	for _, tc := range tcs {
+		tcRef := tc
+
+		t.Run(tcRef.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := append([]Option{WithFunction("concat", concat), WithFunction("explode", explode)}, tcRef.opts...)
+			tree, err := Compile(tcRef.expression, opts...)
+			require.NoError(t, err)
+
+			bytecode, err := Compile(tcRef.expression, append(opts, WithBytecode())...)
+			require.NoError(t, err)
+
+			expected, actual := tree.Eval(env), bytecode.Eval(env)
+
+			var panicErr errs.PanicError
+			if errors.As(expected.Error, &panicErr) {
+				assert.Equal(t, expected.Error.(errs.Error).Span(), actual.Error.(errs.Error).Span())
+				assert.Equal(t, errs.CodeOf(expected.Error), errs.CodeOf(actual.Error))
+
+				return
+			}
+
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func Test_Bytecode_Canceled_During_Evaluation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := func(context.Context, ...interface{}) (interface{}, error) {
+		cancel()
+
+		return true, nil
+	}
+
+	expression := "stop() && stop()"
+	program, err := Compile(expression, WithFunction("stop", stop), WithBytecode())
+	require.NoError(t, err)
+	assert.Equal(t, errorAt(expression, 10, 14, context.Canceled), program.EvalContext(ctx, nil).Error)
+}
+
+func benchmarkBackend(b *testing.B, opts ...Option) {
+	b.Helper()
+
+	expression := "(price * qty + 0x1F) / 3 >= limit && !(country == 'DE' || price - 2 < 1) || qty ** 2 > 1e3"
+	env := Env{"price": 20.5, "qty": 5, "limit": 2, "country": "FR"}
+
+	program, err := Compile(expression, opts...)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if result := program.Eval(env); result.Error != nil {
+			b.Fatal(result.Error)
+		}
+	}
+}
+
+func BenchmarkProgram_Tree(b *testing.B) {
+	benchmarkBackend(b)
+}
+
+func BenchmarkProgram_Bytecode(b *testing.B) {
+	benchmarkBackend(b, WithBytecode())
+}
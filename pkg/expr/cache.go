@@ -0,0 +1,159 @@
+package expr
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// DefaultCacheSize is the number of programs cached by Eval, EvalWithOptions
+// and EvalContext unless set by SetCacheSize.
+const DefaultCacheSize = 512
+
+// CacheStats are the statistics of the program cache.
+type CacheStats struct {
+	// Hits and Misses count the lookups of cacheable expressions.
+	Hits   uint64
+	Misses uint64
+	// Len is the number of cached programs.
+	Len int
+	// Size is the maximum number of cached programs, zero if disabled.
+	Size int
+}
+
+var cache = newProgramCache(DefaultCacheSize)
+
+// SetCacheSize sets the maximum number of programs cached by Eval,
+// EvalWithOptions and EvalContext, the least recently used are evicted.
+// Zero disables and clears the cache.
+func SetCacheSize(size int) {
+	cache.resize(size)
+}
+
+// ClearCache removes all cached programs and resets the statistics.
+func ClearCache() {
+	cache.clear()
+}
+
+// CacheStatistics returns the statistics of the program cache.
+func CacheStatistics() CacheStats {
+	return cache.statistics()
+}
+
+type cacheKey struct {
+	source   string
+	settings settings
+}
+
+type cacheEntry struct {
+	key     cacheKey
+	program *Program
+	err     error
+}
+
+// programCache is a least recently used cache of compiled programs, including
+// the programs that failed to compile. Only programs without environment,
+// functions and resolver are cached, as these options can not be compared.
+type programCache struct {
+	mutex   sync.Mutex
+	size    int
+	entries map[cacheKey]*list.Element
+	// order of the entries, the most recently used first.
+	order  *list.List
+	hits   uint64
+	misses uint64
+}
+
+func newProgramCache(size int) *programCache {
+	return &programCache{
+		size:    size,
+		entries: map[cacheKey]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// compile returns the cached program or compiles and caches it.
+func (c *programCache) compile(ctx context.Context, expression string, cfg *config) (*Program, error) {
+	if cfg.env != nil || cfg.functions != nil || cfg.resolver != nil {
+		return compile(ctx, expression, cfg)
+	}
+
+	key := cacheKey{source: expression, settings: cfg.settings}
+
+	c.mutex.Lock()
+	if c.size <= 0 {
+		c.mutex.Unlock()
+
+		return compile(ctx, expression, cfg)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		c.hits++
+		c.order.MoveToFront(element)
+		c.mutex.Unlock()
+
+		entry := element.Value.(*cacheEntry)
+
+		return entry.program, entry.err
+	}
+
+	c.misses++
+	c.mutex.Unlock()
+
+	// compiled without the lock, concurrent misses of the same expression compile it twice
+	program, err := compile(ctx, expression, cfg)
+	c.add(&cacheEntry{key: key, program: program, err: err})
+
+	return program, err
+}
+
+func (c *programCache) add(entry *cacheEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.entries[entry.key]; ok || c.size <= 0 {
+		return
+	}
+
+	c.entries[entry.key] = c.order.PushFront(entry)
+	c.evict()
+}
+
+// evict removes the least recently used entries exceeding the size.
+func (c *programCache) evict() {
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func (c *programCache) resize(size int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.size = max(size, 0)
+	c.evict()
+}
+
+func (c *programCache) clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = map[cacheKey]*list.Element{}
+	c.order.Init()
+	c.hits = 0
+	c.misses = 0
+}
+
+func (c *programCache) statistics() CacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return CacheStats{
+		Hits:   c.hits,
+		Misses: c.misses,
+		Len:    c.order.Len(),
+		Size:   c.size,
+	}
+}
@@ -0,0 +1,155 @@
+package expr
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Cache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Hit", func(t *testing.T) {
+		t.Parallel()
+
+		c := newProgramCache(2)
+
+		first, err := c.compile(context.Background(), "1 + 2", newConfig())
+		require.NoError(t, err)
+
+		second, err := c.compile(context.Background(), "1 + 2", newConfig())
+		require.NoError(t, err)
+		assert.Same(t, first, second)
+		assert.Equal(t, CacheStats{Hits: 1, Misses: 1, Len: 1, Size: 2}, c.statistics())
+	})
+
+	t.Run("Options_Are_Part_Of_The_Key", func(t *testing.T) {
+		t.Parallel()
+
+		c := newProgramCache(2)
+
+		float, _ := c.compile(context.Background(), "1 + 2", newConfig())
+		integer, _ := c.compile(context.Background(), "1 + 2", newConfig(WithIntegers(OverflowError)))
+		assert.NotSame(t, float, integer)
+		assert.Equal(t, int64(3), integer.Eval(nil).Value)
+		assert.Equal(t, CacheStats{Misses: 2, Len: 2, Size: 2}, c.statistics())
+	})
+
+	t.Run("Least_Recently_Used_Evicted", func(t *testing.T) {
+		t.Parallel()
+
+		c := newProgramCache(2)
+
+		a, _ := c.compile(context.Background(), "a", newConfig())
+		c.compile(context.Background(), "b", newConfig())
+		c.compile(context.Background(), "a", newConfig())
+		c.compile(context.Background(), "c", newConfig())
+
+		again, _ := c.compile(context.Background(), "a", newConfig())
+		assert.Same(t, a, again)
+
+		c.compile(context.Background(), "b", newConfig())
+		assert.Equal(t, CacheStats{Hits: 2, Misses: 4, Len: 2, Size: 2}, c.statistics())
+	})
+
+	t.Run("Errors_Cached", func(t *testing.T) {
+		t.Parallel()
+
+		c := newProgramCache(2)
+
+		_, first := c.compile(context.Background(), "1 +", newConfig())
+		_, second := c.compile(context.Background(), "1 +", newConfig())
+		assert.Equal(t, errorAt("1 +", 3, 3, errs.NewErrUnexpectedInputEnd("literal")), second)
+		assert.Equal(t, first, second)
+		assert.Equal(t, uint64(1), c.statistics().Hits)
+	})
+
+	t.Run("Not_Comparable_Options_Not_Cached", func(t *testing.T) {
+		t.Parallel()
+
+		c := newProgramCache(2)
+
+		resolver := func(_ context.Context, _ string) (interface{}, bool) {
+			return nil, false
+		}
+
+		for _, opt := range []Option{WithEnv(Env{"a": 1}), WithFunction("length", length), WithResolver(resolver)} {
+			c.compile(context.Background(), "1", newConfig(opt))
+			c.compile(context.Background(), "1", newConfig(opt))
+		}
+
+		assert.Equal(t, CacheStats{Size: 2}, c.statistics())
+	})
+
+	t.Run("Resize_And_Disable", func(t *testing.T) {
+		t.Parallel()
+
+		c := newProgramCache(3)
+		c.compile(context.Background(), "a", newConfig())
+		c.compile(context.Background(), "b", newConfig())
+		c.compile(context.Background(), "c", newConfig())
+
+		c.resize(1)
+		assert.Equal(t, CacheStats{Misses: 3, Len: 1, Size: 1}, c.statistics())
+
+		c.resize(0)
+		c.compile(context.Background(), "c", newConfig())
+		c.compile(context.Background(), "c", newConfig())
+		assert.Equal(t, CacheStats{Misses: 3, Len: 0, Size: 0}, c.statistics())
+
+		c.clear()
+		assert.Equal(t, CacheStats{}, c.statistics())
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		t.Parallel()
+
+		c := newProgramCache(8)
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 64; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				program, err := c.compile(context.Background(), fmt.Sprintf("%d + 1", i%16), newConfig())
+				assert.NoError(t, err)
+				assert.Equal(t, float64(i%16+1), program.Eval(nil).Value)
+			}(i)
+		}
+
+		wg.Wait()
+
+		stats := c.statistics()
+		assert.Equal(t, uint64(64), stats.Hits+stats.Misses)
+		assert.Equal(t, 8, stats.Len)
+	})
+}
+
+// Test_Cache_Global is not parallel, as the cache is shared by all tests that evaluate.
+func Test_Cache_Global(t *testing.T) {
+	ClearCache()
+	assert.Equal(t, CacheStats{Size: DefaultCacheSize}, CacheStatistics())
+
+	assert.Equal(t, float64(42), Eval("1 + %d", 41).Value)
+	assert.Equal(t, float64(42), Eval("1 + %d", 41).Value)
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 1, Len: 1, Size: DefaultCacheSize}, CacheStatistics())
+}
+
+func BenchmarkEval_Uncached(b *testing.B) {
+	SetCacheSize(0)
+	defer SetCacheSize(DefaultCacheSize)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		Eval("(1 + 2) * 3 > 4 && 'a' != 'b'")
+	}
+}
@@ -0,0 +1,673 @@
+package expr
+
+import (
+	"context"
+	"errors"
+	"math/bits"
+	"reflect"
+	"slices"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+)
+
+// Columns maps variable names to the columns of a columnar evaluation.
+// Slices are columns of the same length and have a value per row,
+// []float64, []string and []bool are evaluated without conversion.
+// Other values are used in every row, like the variables of an Env.
+type Columns map[string]interface{}
+
+// Bitmap is a set of rows, the bit of a row is set if it is selected.
+type Bitmap []uint64
+
+func newBitmap(rows int) Bitmap {
+	return make(Bitmap, (rows+63)/64)
+}
+
+// Get reports if the row is selected.
+func (b Bitmap) Get(row int) bool {
+	if row < 0 || row/64 >= len(b) {
+		return false
+	}
+
+	return b[row/64]&(1<<(row%64)) != 0
+}
+
+// Count returns the number of selected rows.
+func (b Bitmap) Count() int {
+	count := 0
+	for _, word := range b {
+		count += bits.OnesCount64(word)
+	}
+
+	return count
+}
+
+// Rows returns the selected rows in ascending order.
+func (b Bitmap) Rows() []int {
+	rows := make([]int, 0, b.Count())
+
+	for i, word := range b {
+		for word != 0 {
+			rows = append(rows, i*64+bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+
+	return rows
+}
+
+func (b Bitmap) set(row int) {
+	b[row/64] |= 1 << (row % 64)
+}
+
+// EvalColumns evaluates the program once per operation over all rows of the
+// columns and returns a column of the results, which is a []float64, []string
+// or []bool if all results are of that type, else a []interface{}.
+// The values are the same as of Eval with the variables of each row,
+// except that the tree is evaluated even if compiled WithBytecode.
+// If rows fail, the error of the first of them is returned as errs.RowError.
+func (p *Program) EvalColumns(columns Columns) (interface{}, error) {
+	return p.EvalColumnsContext(context.Background(), columns)
+}
+
+// EvalColumnsContext is like EvalColumns, but stops with the error
+// of the context located at the current operation once it is done.
+func (p *Program) EvalColumnsContext(ctx context.Context, columns Columns) (interface{}, error) {
+	c, err := p.evalColumns(ctx, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.result.slice(c.rows), nil
+}
+
+// Select is like EvalColumns, but returns the rows with a true boolean value.
+func (p *Program) Select(columns Columns) (Bitmap, error) {
+	return p.SelectContext(context.Background(), columns)
+}
+
+// SelectContext is like EvalColumnsContext, but returns the rows with a true boolean value.
+func (p *Program) SelectContext(ctx context.Context, columns Columns) (Bitmap, error) {
+	c, err := p.evalColumns(ctx, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	bitmap := newBitmap(c.rows)
+
+	for row := range c.rows {
+		if c.result.bool(row) {
+			bitmap.set(row)
+		}
+	}
+
+	return bitmap, nil
+}
+
+func (p *Program) evalColumns(ctx context.Context, columns Columns) (*columnEvaluation, error) {
+	rows := -1
+
+	for _, value := range columns {
+		if length, ok := columnLength(value); ok {
+			if rows >= 0 && length != rows {
+				return nil, errs.ErrColumnLength
+			}
+
+			rows = length
+		}
+	}
+
+	c := &columnEvaluation{
+		evaluation: &evaluation{ctx: ctx, config: p.config, env: Env(columns)},
+		columns:    columns,
+		rows:       max(rows, 0),
+		failedRow:  -1,
+	}
+
+	if p.config.maxSteps > 0 {
+		c.steps = make([]int, c.rows)
+	}
+
+	all := make([]int, c.rows)
+	for i := range all {
+		all[i] = i
+	}
+
+	result, _, err := c.evaluate(p.root, all)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.failed != nil {
+		var located errs.Error
+		if errors.As(c.failed, &located) {
+			return nil, errs.NewErrRow(located, c.failedRow)
+		}
+
+		return nil, c.failed
+	}
+
+	c.result = result
+
+	return c, nil
+}
+
+func columnLength(value interface{}) (int, bool) {
+	if value := reflect.ValueOf(value); value.Kind() == reflect.Slice {
+		return value.Len(), true
+	}
+
+	return 0, false
+}
+
+type vectorKind uint8
+
+const (
+	valuesVector vectorKind = iota
+	floatsVector
+	stringsVector
+	boolsVector
+	constantVector
+)
+
+// vector holds the values of a node for all rows of the columns,
+// only the values of the evaluated rows are set.
+type vector struct {
+	kind    vectorKind
+	floats  []float64
+	strings []string
+	bools   []bool
+	values  []interface{}
+	// constant is the value of every row, number is set if it is a float64.
+	constant interface{}
+	number   float64
+	isNumber bool
+}
+
+func newConstant(value interface{}) *vector {
+	number, ok := value.(float64)
+
+	return &vector{kind: constantVector, constant: value, number: number, isNumber: ok}
+}
+
+// newValues stores the values of the rows in the vector of their type,
+// so following operations can use the fast paths.
+func newValues(values []interface{}, rows []int) *vector {
+	var floats, strings, bools bool
+
+	for _, row := range rows {
+		switch values[row].(type) {
+		case float64:
+			floats = true
+		case string:
+			strings = true
+		case bool:
+			bools = true
+		default:
+			return &vector{kind: valuesVector, values: values}
+		}
+	}
+
+	v := &vector{kind: valuesVector, values: values}
+
+	switch {
+	case floats && !strings && !bools:
+		v = &vector{kind: floatsVector, floats: make([]float64, len(values))}
+		for _, row := range rows {
+			v.floats[row] = values[row].(float64)
+		}
+	case strings && !floats && !bools:
+		v = &vector{kind: stringsVector, strings: make([]string, len(values))}
+		for _, row := range rows {
+			v.strings[row] = values[row].(string)
+		}
+	case bools && !floats && !strings:
+		v = &vector{kind: boolsVector, bools: make([]bool, len(values))}
+		for _, row := range rows {
+			v.bools[row] = values[row].(bool)
+		}
+	}
+
+	return v
+}
+
+func (v *vector) value(row int) interface{} {
+	switch v.kind {
+	case floatsVector:
+		return v.floats[row]
+	case stringsVector:
+		return v.strings[row]
+	case boolsVector:
+		return v.bools[row]
+	case constantVector:
+		return v.constant
+	}
+
+	return v.values[row]
+}
+
+// tagged returns the value of the row without boxing numbers and bools.
+func (v *vector) tagged(row int) tagged {
+	switch v.kind {
+	case floatsVector:
+		return floatValue(v.floats[row])
+	case boolsVector:
+		return boolValue(v.bools[row])
+	}
+
+	return valueOf(v.value(row))
+}
+
+func (v *vector) float64s() bool {
+	return v.kind == floatsVector || v.isNumber
+}
+
+func (v *vector) float(row int) float64 {
+	if v.kind == floatsVector {
+		return v.floats[row]
+	}
+
+	return v.number
+}
+
+func (v *vector) bool(row int) bool {
+	if v.kind == boolsVector {
+		return v.bools[row]
+	}
+
+	return convertBool(v.value(row))
+}
+
+// slice returns a copy of the values as slice of their type.
+func (v *vector) slice(rows int) interface{} {
+	switch v.kind {
+	case floatsVector:
+		return slices.Clone(v.floats[:rows])
+	case stringsVector:
+		return slices.Clone(v.strings[:rows])
+	case boolsVector:
+		return slices.Clone(v.bools[:rows])
+	case constantVector:
+		return broadcast(v.constant, rows)
+	}
+
+	return append(make([]interface{}, 0, rows), v.values[:rows]...)
+}
+
+// broadcast returns a slice of the type of the value with it in every row.
+func broadcast(value interface{}, rows int) interface{} {
+	switch value := value.(type) {
+	case float64:
+		return repeat(value, rows)
+	case string:
+		return repeat(value, rows)
+	case bool:
+		return repeat(value, rows)
+	}
+
+	return repeat(value, rows)
+}
+
+func repeat[T any](value T, rows int) []T {
+	values := make([]T, rows)
+	for i := range values {
+		values[i] = value
+	}
+
+	return values
+}
+
+// columnEvaluation evaluates every node once for the selected rows.
+// Rows that fail are removed from the selection of the following nodes,
+// so every row fails with the same error as its scalar evaluation.
+type columnEvaluation struct {
+	*evaluation
+	columns Columns
+	rows    int
+	// steps of every row, only counted if limited.
+	steps []int
+	// failed is the error of the first failed row.
+	failed    error
+	failedRow int
+	result    *vector
+}
+
+// fail records the error of the row.
+func (c *columnEvaluation) fail(row int, err error) {
+	if c.failedRow < 0 || row < c.failedRow {
+		c.failed = err
+		c.failedRow = row
+	}
+}
+
+// failAll records the error for all rows, as only the first one is returned
+// it is recorded for it.
+func (c *columnEvaluation) failAll(rows []int, err error) []int {
+	if len(rows) > 0 {
+		c.fail(rows[0], err)
+	}
+
+	return nil
+}
+
+// each calls the function for every row and returns the rows that did not fail.
+func (c *columnEvaluation) each(rows []int, f func(row int) error) []int {
+	var kept []int
+
+	for i, row := range rows {
+		if err := f(row); err != nil {
+			c.fail(row, err)
+
+			if kept == nil {
+				kept = append(make([]int, 0, len(rows)), rows[:i]...)
+			}
+
+			continue
+		}
+
+		if kept != nil {
+			kept = append(kept, row)
+		}
+	}
+
+	if kept == nil {
+		return rows
+	}
+
+	return kept
+}
+
+// evaluate returns the vector of the node and the rows that did not fail.
+// The error is only returned if the context is done.
+func (c *columnEvaluation) evaluate(n *Node, rows []int) (*vector, []int, error) {
+	if n.Kind == LiteralNode {
+		return newConstant(n.Value), rows, nil
+	}
+
+	// all rows failed already
+	if len(rows) == 0 && c.rows > 0 {
+		return &vector{}, rows, nil
+	}
+
+	rows, err := c.enter(n, rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch n.Kind {
+	case IdentifierNode:
+		v, rows := c.variable(n, rows)
+
+		return v, rows, nil
+	case CallNode:
+		return c.call(n, rows)
+	case ErrorNode:
+		return &vector{}, c.failAll(rows, n.Err), nil
+	}
+
+	left, rows, err := c.evaluate(n.Left, rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if n.Kind == UnaryNode {
+		result := &vector{kind: boolsVector, bools: make([]bool, c.rows)}
+		for _, row := range rows {
+			result.bools[row] = !left.bool(row)
+		}
+
+		return result, rows, nil
+	}
+
+	if operators[n.Operator].tokenType == logicalOperationType {
+		return c.logicalOperation(n, left, rows)
+	}
+
+	right, rows, err := c.evaluate(n.Right, rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if result := c.fastBinary(n, left, right, rows); result != nil {
+		return result, rows, nil
+	}
+
+	op := binaryOpcode(n.Operator)
+	values := make([]interface{}, c.rows)
+	rows = c.each(rows, func(row int) error {
+		value, err := c.binary(n, op, left.tagged(row), right.tagged(row))
+		values[row] = value.boxed()
+
+		return err
+	})
+
+	return newValues(values, rows), rows, nil
+}
+
+// enter checks the context once and counts the step of the node for every row.
+func (c *columnEvaluation) enter(n *Node, rows []int) ([]int, error) {
+	select {
+	case <-c.ctx.Done():
+		return nil, errs.NewErrorAtSpan(c.ctx.Err(), n.Span)
+	default:
+	}
+
+	if c.steps == nil {
+		return rows, nil
+	}
+
+	return c.each(rows, func(row int) error {
+		if c.steps[row]++; c.steps[row] > c.config.maxSteps {
+			return errs.NewErrorAtSpan(errs.ErrMaxSteps, n.Span)
+		}
+
+		return nil
+	}), nil
+}
+
+// variable returns the column, or the value of the resolver for every row.
+func (c *columnEvaluation) variable(n *Node, rows []int) (*vector, []int) {
+	value, ok := c.columns[n.Name]
+	if !ok {
+		if c.config.resolver == nil {
+			_, err := c.evaluation.variable(n)
+
+			return &vector{}, c.failAll(rows, err)
+		}
+
+		values := make([]interface{}, c.rows)
+		rows = c.each(rows, func(row int) error {
+			value, err := c.evaluation.variable(n)
+			values[row] = value.boxed()
+
+			return err
+		})
+
+		return newValues(values, rows), rows
+	}
+
+	var v *vector
+
+	switch column := value.(type) {
+	case []float64:
+		v = &vector{kind: floatsVector, floats: column}
+	case []string:
+		v = &vector{kind: stringsVector, strings: column}
+	case []bool:
+		v = &vector{kind: boolsVector, bools: column}
+	default:
+		reflected := reflect.ValueOf(value)
+		if reflected.Kind() != reflect.Slice {
+			constant := normalizeValue(c.config, value)
+			if err := c.checkString(n, constant); err != nil {
+				return &vector{}, c.failAll(rows, err)
+			}
+
+			return newConstant(constant.boxed()), rows
+		}
+
+		values := make([]interface{}, c.rows)
+		for _, row := range rows {
+			values[row] = normalize(c.config, reflected.Index(row).Interface())
+		}
+
+		v = newValues(values, rows)
+	}
+
+	if c.config.maxStringLength > 0 && (v.kind == stringsVector || v.kind == valuesVector) {
+		rows = c.each(rows, func(row int) error {
+			return c.checkString(n, v.tagged(row))
+		})
+	}
+
+	return v, rows
+}
+
+// call evaluates the arguments for every row and calls the function with them.
+func (c *columnEvaluation) call(n *Node, rows []int) (*vector, []int, error) {
+	args := make([]*vector, len(n.Args))
+
+	for i, arg := range n.Args {
+		var err error
+
+		if args[i], rows, err = c.evaluate(arg, rows); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	values := make([]interface{}, c.rows)
+	rows = c.each(rows, func(row int) error {
+		scalar := make([]interface{}, len(args))
+
+		for i, arg := range args {
+			scalar[i] = arg.value(row)
+		}
+
+		value, err := c.invoke(n, scalar)
+		values[row] = value.boxed()
+
+		return err
+	})
+
+	return newValues(values, rows), rows, nil
+}
+
+// logicalOperation evaluates the right side only for the rows that do not short-circuit.
+func (c *columnEvaluation) logicalOperation(n *Node, left *vector, rows []int) (*vector, []int, error) {
+	short := n.Operator == "||"
+	result := &vector{kind: boolsVector, bools: make([]bool, c.rows)}
+
+	var (
+		shorted []int
+		needed  []int
+	)
+
+	for _, row := range rows {
+		if left.bool(row) == short {
+			result.bools[row] = short
+			shorted = append(shorted, row)
+		} else {
+			needed = append(needed, row)
+		}
+	}
+
+	if len(needed) == 0 {
+		return result, rows, nil
+	}
+
+	right, needed, err := c.evaluate(n.Right, needed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, row := range needed {
+		result.bools[row] = right.bool(row)
+	}
+
+	return result, merge(shorted, needed), nil
+}
+
+// fastBinary applies operations that can not fail on float64 operands,
+// it returns nil for other operations and operands.
+func (c *columnEvaluation) fastBinary(n *Node, left, right *vector, rows []int) *vector {
+	if !left.float64s() || !right.float64s() {
+		return nil
+	}
+
+	var floats, bools *vector
+
+	switch n.Operator {
+	case "+", "-", "*":
+		floats = &vector{kind: floatsVector, floats: make([]float64, c.rows)}
+	case "==", "!=", "<", "<=", ">", ">=":
+		bools = &vector{kind: boolsVector, bools: make([]bool, c.rows)}
+	default:
+		return nil
+	}
+
+	switch n.Operator {
+	case "+":
+		for _, row := range rows {
+			floats.floats[row] = left.float(row) + right.float(row)
+		}
+	case "-":
+		for _, row := range rows {
+			floats.floats[row] = left.float(row) - right.float(row)
+		}
+	case "*":
+		for _, row := range rows {
+			floats.floats[row] = left.float(row) * right.float(row)
+		}
+	case "==":
+		for _, row := range rows {
+			bools.bools[row] = left.float(row) == right.float(row)
+		}
+	case "!=":
+		for _, row := range rows {
+			bools.bools[row] = left.float(row) != right.float(row)
+		}
+	case "<":
+		for _, row := range rows {
+			bools.bools[row] = left.float(row) < right.float(row)
+		}
+	case "<=":
+		for _, row := range rows {
+			bools.bools[row] = left.float(row) <= right.float(row)
+		}
+	case ">":
+		for _, row := range rows {
+			bools.bools[row] = left.float(row) > right.float(row)
+		}
+	case ">=":
+		for _, row := range rows {
+			bools.bools[row] = left.float(row) >= right.float(row)
+		}
+	}
+
+	if bools != nil {
+		return bools
+	}
+
+	return floats
+}
+
+// merge merges the ascending rows.
+func merge(a, b []int) []int {
+	if len(a) == 0 {
+		return b
+	}
+
+	merged := make([]int, 0, len(a)+len(b))
+
+	for len(a) > 0 && len(b) > 0 {
+		if a[0] < b[0] {
+			merged = append(merged, a[0])
+			a = a[1:]
+		} else {
+			merged = append(merged, b[0])
+			b = b[1:]
+		}
+	}
+
+	return append(append(merged, a...), b...)
+}
@@ -0,0 +1,356 @@
+package expr
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rowEnv returns the variables of the row of the columns.
+func rowEnv(columns Columns, row int) Env {
+	env := Env{}
+
+	for name, value := range columns {
+		if reflected := reflect.ValueOf(value); reflected.Kind() == reflect.Slice {
+			env[name] = reflected.Index(row).Interface()
+		} else {
+			env[name] = value
+		}
+	}
+
+	return env
+}
+
+// requireLikeScalar compares the columnar evaluation with the evaluation of every row.
+func requireLikeScalar(t *testing.T, program *Program, columns Columns, rows int) {
+	t.Helper()
+
+	actual, err := program.EvalColumns(columns)
+	expected := make([]interface{}, rows)
+
+	for row := range expected {
+		result := program.Eval(rowEnv(columns, row))
+		if result.Error != nil {
+			// compared by message, the stacks of panics differ
+			var located errs.Error
+			require.ErrorAs(t, result.Error, &located)
+			require.Error(t, err)
+			require.Equal(t, errs.NewErrRow(located, row).Error(), err.Error())
+			require.Equal(t, errs.NewErrRow(located, row).Details(), errs.ToJSON(err).Details)
+
+			return
+		}
+
+		expected[row] = result.Value
+	}
+
+	require.NoError(t, err)
+	require.Equal(t, rows, reflect.ValueOf(actual).Len())
+
+	for row, value := range expected {
+		if isNaN(value) {
+			assert.True(t, isNaN(reflect.ValueOf(actual).Index(row).Interface()), "row %d", row)
+
+			continue
+		}
+
+		assert.Equal(t, value, reflect.ValueOf(actual).Index(row).Interface(), "row %d", row)
+	}
+}
+
+func Test_Program_EvalColumns(t *testing.T) {
+	t.Parallel()
+
+	columns := Columns{
+		"price": []float64{10, 0, -2.5, 100, math.NaN(), 3},
+		"qty":   []float64{20, 5, 4, 0, 1, 40},
+		"count": []int{1, 2, 3, 0, -1, 7},
+		"name":  []string{"a", "TRUE", "", "abc", "b", "long"},
+		"flag":  []bool{true, false, true, false, true, false},
+		"mixed": []interface{}{1, "x", true, nil, 2.5, "y"},
+		"limit": 100,
+	}
+
+	tcs := []struct {
+		name       string
+		expression string
+	}{
+		{name: "Arithmetic", expression: "price * qty > limit"},
+		{name: "Floats", expression: "price + qty - 1 * qty"},
+		{name: "Comparisons", expression: "price == qty || price != 3 && price <= qty && price >= 0 && price < 100"},
+		{name: "Division", expression: "qty / 2 + qty // 3 + count % 2 + 2 ** count"},
+		{name: "Division_By_Zero", expression: "price / qty"},
+		{name: "Division_By_Zero_Short_Circuit", expression: "qty == 0 || price / qty > 1"},
+		{name: "Bitwise", expression: "count & 3 | 8"},
+		{name: "Not_Integer", expression: "price & 1"},
+		{name: "Integer_Column", expression: "count * 2 >= 4"},
+		{name: "Strings", expression: "name == 'abc' || name < 'xx'"},
+		{name: "String_Concat", expression: "concat(name, '!')"},
+		{name: "Bools", expression: "!flag && name"},
+		{name: "Mixed", expression: "mixed"},
+		{name: "Mixed_Arithmetic", expression: "mixed + 1"},
+		{name: "Calls", expression: "length(name) * 2 > qty && length(concat(name, name)) > 1"},
+		{name: "Constant", expression: "limit + 1"},
+		{name: "Literal", expression: "1 + 2"},
+		{name: "Text_Literal", expression: "'text'"},
+		{name: "Column", expression: "price"},
+		{name: "Unknown", expression: "flag || unknown"},
+		{name: "Unknown_Suggestion", expression: "pric * 2"},
+		{name: "Failing_Call", expression: "qty > 10 && fail(price)"},
+		{name: "Panic", expression: "explode(qty)"},
+	}
+
+	fail := func(context.Context, ...interface{}) (interface{}, error) {
+		return nil, errors.New("fail")
+	}
+	explode := func(_ context.Context, args ...interface{}) (interface{}, error) {
+		if args[0] == 0.0 {
+			panic("explode")
+		}
+
+		return args[0], nil
+	}
+
+	for _, backend := range backends {
+		for _, tc := range tcs {
+			tcRef := tc
+
+			t.Run(backend.name+"_"+tcRef.name, func(t *testing.T) {
+				t.Parallel()
+
+				program, err := Compile(tcRef.expression, append(backend.opts,
+					WithFunction("length", length), WithFunction("concat", concat),
+					WithFunction("fail", fail), WithFunction("explode", explode))...)
+				require.NoError(t, err)
+
+				requireLikeScalar(t, program, columns, 6)
+			})
+		}
+	}
+}
+
+func Test_Program_EvalColumns_Options(t *testing.T) {
+	t.Parallel()
+
+	columns := Columns{
+		"a":    []int64{1, math.MaxInt64, 3},
+		"text": []string{"ab", "abcdef", "a"},
+	}
+	resolver := func(_ context.Context, name string) (interface{}, bool) {
+		return len(name), name != "missing"
+	}
+
+	tcs := []struct {
+		name       string
+		expression string
+		opts       []Option
+	}{
+		{name: "Integers", expression: "a * 2", opts: []Option{WithIntegers(OverflowError)}},
+		{name: "Integers_Promote", expression: "a * 2", opts: []Option{WithIntegers(OverflowPromote)}},
+		{name: "Max_Steps", expression: "text == 'a' || a + 1 + 1 > 0", opts: []Option{WithMaxSteps(4)}},
+		{name: "Max_String_Length", expression: "a > 2 || text", opts: []Option{WithMaxStringLength(3)}},
+		{name: "Resolver", expression: "a + resolved", opts: []Option{WithResolver(resolver)}},
+		{name: "Resolver_Missing", expression: "a > 2 || missing", opts: []Option{WithResolver(resolver)}},
+	}
+
+	for _, tc := range tcs {
+		tcRef := tc
+
+		t.Run(tcRef.name, func(t *testing.T) {
+			t.Parallel()
+
+			program, err := Compile(tcRef.expression, tcRef.opts...)
+			require.NoError(t, err)
+
+			requireLikeScalar(t, program, columns, 3)
+		})
+	}
+}
+
+func Test_Program_EvalColumns_Result(t *testing.T) {
+	t.Parallel()
+
+	program, err := Compile("price * qty > 100")
+	require.NoError(t, err)
+
+	values, err := program.EvalColumns(Columns{"price": []float64{10, 20}, "qty": []float64{10, 6}})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false, true}, values)
+
+	values, err = program.EvalColumns(Columns{"price": []float64{}, "qty": []float64{}})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{}, values)
+
+	// without slice columns there are no rows
+	values, err = program.EvalColumns(Columns{"price": 20, "qty": 10})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{}, values)
+
+	price := []float64{1, 2}
+	program, err = Compile("price")
+	require.NoError(t, err)
+
+	values, err = program.EvalColumns(Columns{"price": price})
+	require.NoError(t, err)
+	values.([]float64)[0] = 3
+	assert.Equal(t, []float64{1, 2}, price)
+
+	_, err = program.EvalColumns(Columns{"price": price, "qty": []int{1}})
+	require.ErrorIs(t, err, errs.ErrColumnLength)
+	assert.Equal(t, errs.CodeColumnLength, errs.CodeOf(err))
+}
+
+func Test_Program_EvalColumns_Row_Error(t *testing.T) {
+	t.Parallel()
+
+	program, err := Compile("price / qty")
+	require.NoError(t, err)
+
+	_, err = program.EvalColumns(Columns{"price": []float64{1, 2, 3, 4}, "qty": []float64{1, 2, 0, 0}})
+
+	var rowErr errs.RowError
+	require.ErrorAs(t, err, &rowErr)
+	assert.Equal(t, 2, rowErr.Row())
+	assert.ErrorIs(t, err, errs.ErrDivisionByZero)
+	assert.Equal(t, "division by zero, at line 1, column 7, in row 2", err.Error())
+}
+
+func Test_Program_EvalColumns_Short_Circuit(t *testing.T) {
+	t.Parallel()
+
+	var rows []interface{}
+
+	record := func(_ context.Context, args ...interface{}) (interface{}, error) {
+		rows = append(rows, args[0])
+
+		return true, nil
+	}
+
+	program, err := Compile("id > 2 && record(id)", WithImpureFunction("record", record))
+	require.NoError(t, err)
+
+	values, err := program.EvalColumns(Columns{"id": []float64{1, 2, 3, 4, 0, 5}})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false, false, true, true, false, true}, values)
+	assert.Equal(t, []interface{}{3.0, 4.0, 5.0}, rows)
+}
+
+func Test_Program_EvalColumns_Context(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	program, err := Compile("price * 2")
+	require.NoError(t, err)
+
+	_, err = program.EvalColumnsContext(ctx, Columns{"price": []float64{1}})
+	assert.Equal(t, errorAt(program.Source(), 6, 7, context.Canceled), err)
+
+	_, err = program.SelectContext(ctx, Columns{"price": []float64{1}})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_Program_Select(t *testing.T) {
+	t.Parallel()
+
+	program, err := Compile("id % 3 == 0 && name != 'skip'")
+	require.NoError(t, err)
+
+	columns := Columns{"id": make([]int, 200), "name": make([]string, 200)}
+	for i := range 200 {
+		columns["id"].([]int)[i] = i
+		columns["name"].([]string)[i] = "n"
+	}
+
+	columns["name"].([]string)[99] = "skip"
+
+	bitmap, err := program.Select(columns)
+	require.NoError(t, err)
+	require.Len(t, bitmap, 4)
+
+	expected := []int{}
+
+	for row := range 200 {
+		selected := program.Eval(rowEnv(columns, row)).MustBool()
+		assert.Equal(t, selected, bitmap.Get(row), "row %d", row)
+
+		if selected {
+			expected = append(expected, row)
+		}
+	}
+
+	assert.Equal(t, expected, bitmap.Rows())
+	assert.Equal(t, len(expected), bitmap.Count())
+	assert.False(t, bitmap.Get(-1))
+	assert.False(t, bitmap.Get(256))
+
+	_, err = program.Select(Columns{"id": []float64{1, 0}, "name": 1})
+	require.NoError(t, err)
+
+	_, err = program.Select(Columns{"id": []float64{0}})
+
+	var rowErr errs.RowError
+	require.ErrorAs(t, err, &rowErr)
+	assert.Equal(t, 0, rowErr.Row())
+}
+
+func BenchmarkProgram_EvalColumns(b *testing.B) {
+	program, columns := benchmarkColumns(b)
+
+	for i := 0; i < b.N; i++ {
+		if _, err := program.EvalColumns(columns); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkProgram_Select(b *testing.B) {
+	program, columns := benchmarkColumns(b)
+
+	for i := 0; i < b.N; i++ {
+		if _, err := program.Select(columns); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkProgram_EvalColumns_Scalar(b *testing.B) {
+	program, columns := benchmarkColumns(b)
+	price, qty := columns["price"].([]float64), columns["qty"].([]float64)
+	env := Env{}
+
+	for i := 0; i < b.N; i++ {
+		values := make([]bool, len(price))
+
+		for row := range price {
+			env["price"], env["qty"] = price[row], qty[row]
+			values[row] = program.Eval(env).Value.(bool)
+		}
+	}
+}
+
+func benchmarkColumns(b *testing.B) (*Program, Columns) {
+	b.Helper()
+
+	program, err := Compile("price * qty > 100", WithBytecode())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	price, qty := make([]float64, 100_000), make([]float64, 100_000)
+	for i := range price {
+		price[i], qty[i] = float64(i%50), float64(i%7)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	return program, Columns{"price": price, "qty": qty}
+}
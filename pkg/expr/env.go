@@ -0,0 +1,87 @@
+package expr
+
+import (
+	"context"
+	"math/big"
+	"slices"
+)
+
+// Env maps variable names to their values. Go numbers are evaluated as float64,
+// or int64 if integers are enabled, other types than strings, bools and *big.Int
+// are only passed through to functions.
+type Env map[string]interface{}
+
+// Function is called with the context of the evaluation and the evaluated
+// arguments, numbers are float64, int64 or *big.Int. A returned error is
+// located at the call. It must be safe for concurrent use if programs
+// calling it are evaluated concurrently.
+type Function func(ctx context.Context, args ...interface{}) (interface{}, error)
+
+// Resolver is called with the context of the evaluation for variables that are
+// not in the environment, it returns false if the variable is unknown.
+// Like a Function it must be safe for concurrent use.
+type Resolver func(ctx context.Context, name string) (interface{}, bool)
+
+// names returns the variable names sorted.
+func (env Env) names() []string {
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// normalize converts Go values to the types used in evaluation.
+func normalize(cfg *config, value interface{}) interface{} {
+	var integer int64
+
+	switch v := value.(type) {
+	case int:
+		integer = int64(v)
+	case int8:
+		integer = int64(v)
+	case int16:
+		integer = int64(v)
+	case int32:
+		integer = int64(v)
+	case int64:
+		integer = v
+	case uint:
+		return normalizeUint(cfg, uint64(v))
+	case uint8:
+		integer = int64(v)
+	case uint16:
+		integer = int64(v)
+	case uint32:
+		integer = int64(v)
+	case uint64:
+		return normalizeUint(cfg, v)
+	case float32:
+		return float64(v)
+	case *big.Int:
+		if !cfg.integers {
+			return convertFloat(v)
+		}
+
+		return normalizeBigInt(v)
+	default:
+		return value
+	}
+
+	if !cfg.integers {
+		return float64(integer)
+	}
+
+	return integer
+}
+
+func normalizeUint(cfg *config, value uint64) interface{} {
+	if !cfg.integers {
+		return float64(value)
+	}
+
+	return normalizeBigInt(new(big.Int).SetUint64(value))
+}
@@ -0,0 +1,89 @@
+package expr
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	shortUnicodeLength = 4
+	longUnicodeLength  = 8
+)
+
+var simpleEscapes = map[byte]byte{
+	'a':  '\a',
+	'b':  '\b',
+	'f':  '\f',
+	'n':  '\n',
+	'r':  '\r',
+	't':  '\t',
+	'v':  '\v',
+	'\\': '\\',
+	'\'': '\'',
+	'"':  '"',
+}
+
+// unquote removes the quotes of a text literal and resolves escape sequences,
+// raw strings in backticks are returned as they are. On an invalid escape
+// sequence it returns the sequence and its index within the literal.
+func unquote(literal string) (string, string, int) {
+	content := literal[1 : len(literal)-1]
+	if literal[0] == '`' || !strings.Contains(content, `\`) {
+		return content, "", -1
+	}
+
+	var builder strings.Builder
+
+	builder.Grow(len(content))
+
+	for index := 0; index < len(content); index++ {
+		if content[index] != '\\' {
+			builder.WriteByte(content[index])
+
+			continue
+		}
+
+		// the tokenizer guarantees a character after each backslash
+		escape := content[index+1]
+		if char, ok := simpleEscapes[escape]; ok {
+			builder.WriteByte(char)
+			index++
+
+			continue
+		}
+
+		length := 0
+
+		switch escape {
+		case 'u':
+			length = shortUnicodeLength
+		case 'U':
+			length = longUnicodeLength
+		}
+
+		if length == 0 {
+			// the sequence covers the whole character, which may be multibyte
+			_, size := utf8.DecodeRuneInString(content[index+1:])
+
+			return "", content[index : index+1+size], index + 1
+		}
+
+		end := min(index+2+length, len(content))
+		sequence := content[index:end]
+
+		if len(sequence) != length+2 {
+			return "", sequence, index + 1
+		}
+
+		code, err := strconv.ParseUint(sequence[2:], hexadecimalBase, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return "", sequence, index + 1
+		}
+
+		builder.WriteRune(rune(code))
+		index += length + 1
+	}
+
+	return builder.String(), "", -1
+}
@@ -0,0 +1,45 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unquote(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string
+		literal  string
+		expect   string
+		sequence string
+		invalid  int
+	}{
+		{name: "Plain", literal: `'hello'`, expect: "hello", invalid: -1},
+		{name: "Escaped_Quote", literal: `'it\'s'`, expect: "it's", invalid: -1},
+		{name: "Escaped_Double_Quote", literal: `"say \"hi\""`, expect: `say "hi"`, invalid: -1},
+		{name: "Escaped_Backslash", literal: `'a\\b'`, expect: `a\b`, invalid: -1},
+		{name: "Control_Characters", literal: `'\n\t\r'`, expect: "\n\t\r", invalid: -1},
+		{name: "Unicode", literal: `'\u00e4\U0001F600'`, expect: "ä😀", invalid: -1},
+		{name: "Raw", literal: "`C:\\temp\\new`", expect: `C:\temp\new`, invalid: -1},
+		{name: "Unknown_Escape", literal: `'a\qb'`, sequence: `\q`, invalid: 2},
+		{name: "Unknown_Multibyte_Escape", literal: `'\éa'`, sequence: `\é`, invalid: 1},
+		{name: "Short_Unicode", literal: `'\u00e'`, sequence: `\u00e`, invalid: 1},
+		{name: "Invalid_Unicode_Digit", literal: `'\u00eg'`, sequence: `\u00eg`, invalid: 1},
+		{name: "Surrogate_Unicode", literal: `'\ud800'`, sequence: `\ud800`, invalid: 1},
+	}
+
+	for _, tc := range tcs {
+		tcRef := tc
+
+		t.Run(tcRef.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, sequence, invalid := unquote(tcRef.literal)
+			assert.Equal(t, tcRef.invalid, invalid)
+			assert.Equal(t, tcRef.sequence, sequence)
+			assert.Equal(t, tcRef.expect, actual)
+		})
+	}
+}
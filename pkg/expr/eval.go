@@ -0,0 +1,263 @@
+package expr
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+)
+
+// evaluation of a tree with an environment.
+type evaluation struct {
+	ctx    context.Context
+	config *config
+	env    Env
+	steps  int
+}
+
+// evaluate walks the tree and returns the resulting value.
+// The context and the steps are checked before every operation, variable and call.
+func (e *evaluation) evaluate(n *Node) (tagged, error) {
+	if n.Kind == LiteralNode {
+		return valueOf(n.Value), nil
+	}
+
+	if err := e.enter(n); err != nil {
+		return tagged{}, err
+	}
+
+	switch n.Kind {
+	case IdentifierNode:
+		return e.variable(n)
+	case CallNode:
+		return e.call(n)
+	case ErrorNode:
+		return tagged{}, n.Err
+	}
+
+	left, err := e.evaluate(n.Left)
+	if err != nil {
+		return tagged{}, err
+	}
+
+	if n.Kind == UnaryNode {
+		return boolValue(!left.bool()), nil
+	}
+
+	if operators[n.Operator].tokenType == logicalOperationType {
+		return e.logicalOperation(n, left)
+	}
+
+	right, err := e.evaluate(n.Right)
+	if err != nil {
+		return tagged{}, err
+	}
+
+	return e.binary(n, binaryOpcode(n.Operator), left, right)
+}
+
+// enter checks the context and counts the step before a node is evaluated.
+func (e *evaluation) enter(n *Node) error {
+	select {
+	case <-e.ctx.Done():
+		return errs.NewErrorAtSpan(e.ctx.Err(), n.Span)
+	default:
+	}
+
+	return e.step(n)
+}
+
+// binary applies the operation without boxing float64 and int64 operands,
+// other operands fall back to the functions on interface{} values.
+func (e *evaluation) binary(n *Node, op opcode, left, right tagged) (tagged, error) {
+	switch {
+	case left.kind == intKind && right.kind == intKind && e.config.integers:
+		if value, ok := e.integers(n, op, left.int64(), right.int64()); ok {
+			return value, nil
+		}
+	case left.isNumber() && right.isNumber():
+		return e.floats(n, op, left.float(), right.float())
+	}
+
+	if op >= opEqual {
+		return boolValue(comparisonOperation(n.Operator, left.boxed(), right.boxed())), nil
+	}
+
+	return e.arithmetic(n, left, right)
+}
+
+// floats applies the operation like arithmetic and comparisonOperation on float64 values.
+func (e *evaluation) floats(n *Node, op opcode, l, r float64) (tagged, error) {
+	// comparisons of float64 follow IEEE 754 like comparisonOperation
+	switch op {
+	case opEqual:
+		return boolValue(l == r), nil
+	case opNotEqual:
+		return boolValue(l != r), nil
+	case opLess:
+		return boolValue(l < r), nil
+	case opLessEqual:
+		return boolValue(l <= r), nil
+	case opGreater:
+		return boolValue(l > r), nil
+	case opGreaterEqual:
+		return boolValue(l >= r), nil
+	}
+
+	value, err := floatOperation(n.Operator, l, r)
+	if err != nil {
+		return tagged{}, errs.NewErrorAtSpan(err, n.Span)
+	}
+
+	return value, nil
+}
+
+// integers applies the operation on int64 values, it returns false
+// if it must fall back to arithmetic, e.g. on errors and overflows.
+func (e *evaluation) integers(n *Node, op opcode, l, r int64) (tagged, bool) {
+	switch op {
+	case opEqual:
+		return boolValue(l == r), true
+	case opNotEqual:
+		return boolValue(l != r), true
+	case opLess:
+		return boolValue(l < r), true
+	case opLessEqual:
+		return boolValue(l <= r), true
+	case opGreater:
+		return boolValue(l > r), true
+	case opGreaterEqual:
+		return boolValue(l >= r), true
+	case opDivide:
+		if r != 0 {
+			return floatValue(float64(l) / float64(r)), true
+		}
+
+		return tagged{}, false
+	}
+
+	if n.Operator == "**" && r < 0 {
+		return tagged{}, false
+	}
+
+	value, ok, err := int64Arithmetic(n.Operator, l, r)
+	if err != nil || !ok {
+		return tagged{}, false
+	}
+
+	return intValue(value), true
+}
+
+// arithmetic applies the operator of the node and locates the error at it.
+func (e *evaluation) arithmetic(n *Node, left, right tagged) (tagged, error) {
+	value, err := arithmetic(e.config, n.Operator, left.boxed(), right.boxed())
+	if err != nil {
+		return tagged{}, errs.NewErrorAtSpan(err, n.Span)
+	}
+
+	return valueOf(value), nil
+}
+
+// variable looks up the value in the environment or by the resolver.
+func (e *evaluation) variable(n *Node) (value tagged, err error) {
+	raw, ok := e.env[n.Name]
+	if !ok && e.config.resolver != nil {
+		defer e.recover(n, &err)
+
+		raw, ok = e.config.resolver(e.ctx, n.Name)
+	}
+
+	if !ok {
+		return tagged{}, errs.NewErrorAtSpan(
+			errs.NewErrUnknownIdentifier(n.Name, suggest(n.Name, e.env.names())...),
+			n.Span)
+	}
+
+	value = normalizeValue(e.config, raw)
+	if err := e.checkString(n, value); err != nil {
+		return tagged{}, err
+	}
+
+	return value, nil
+}
+
+// call evaluates the arguments and calls the function with them.
+func (e *evaluation) call(n *Node) (tagged, error) {
+	args := make([]interface{}, len(n.Args))
+
+	for i, arg := range n.Args {
+		value, err := e.evaluate(arg)
+		if err != nil {
+			return tagged{}, err
+		}
+
+		args[i] = value.boxed()
+	}
+
+	return e.invoke(n, args)
+}
+
+// invoke calls the function of the node with the evaluated arguments.
+func (e *evaluation) invoke(n *Node, args []interface{}) (value tagged, err error) {
+	defer e.recover(n, &err)
+
+	raw, err := e.config.functions[n.Name](e.ctx, args...)
+	if err != nil {
+		return tagged{}, errs.NewErrorAtSpan(err, n.Span)
+	}
+
+	value = normalizeValue(e.config, raw)
+	if err := e.checkString(n, value); err != nil {
+		return tagged{}, err
+	}
+
+	return value, nil
+}
+
+// recover turns a panic into an error located at the node,
+// it must be deferred directly.
+func (e *evaluation) recover(n *Node, err *error) {
+	if e.config.panics {
+		return
+	}
+
+	if value := recover(); value != nil {
+		*err = errs.NewErrorAtSpan(errs.NewErrPanic(value, debug.Stack()), n.Span)
+	}
+}
+
+// logicalOperation short-circuits, the right side is only evaluated if needed.
+func (e *evaluation) logicalOperation(n *Node, left tagged) (tagged, error) {
+	if left.bool() == (n.Operator == "||") {
+		return boolValue(n.Operator == "||"), nil
+	}
+
+	right, err := e.evaluate(n.Right)
+	if err != nil {
+		return tagged{}, err
+	}
+
+	return boolValue(right.bool()), nil
+}
+
+// comparisonOperation follows IEEE 754 for NaN, only `!=` is true.
+func comparisonOperation(operator string, left, right interface{}) bool {
+	if isNaN(left) || isNaN(right) {
+		return operator == "!="
+	}
+
+	switch operator {
+	case "==":
+		return equal(left, right)
+	case "!=":
+		return !equal(left, right)
+	case "<":
+		return compare(left, right) < 0
+	case "<=":
+		return compare(left, right) <= 0
+	case ">":
+		return compare(left, right) > 0
+	}
+
+	return compare(left, right) >= 0
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// significance is the p-value below which a change is reported.
+const significance = 0.05
+
+// metrics are the names of the common units.
+var metrics = map[string]string{
+	"ns/op":     "time/op",
+	"B/op":      "alloc/op",
+	"allocs/op": "allocs/op",
+}
+
+// summary of the samples of a benchmark without outliers.
+type summary struct {
+	values []float64
+	mean   float64
+	// variation is the largest deviation from the mean in percent.
+	variation float64
+}
+
+// summarize removes the outliers outside of 1.5 times the interquartile range.
+func summarize(samples []float64) summary {
+	sorted := slices.Sorted(slices.Values(samples))
+	q1, q3 := quantile(sorted, 0.25), quantile(sorted, 0.75)
+	low, high := q1-1.5*(q3-q1), q3+1.5*(q3-q1)
+
+	s := summary{}
+
+	for _, value := range sorted {
+		if value >= low && value <= high {
+			s.values = append(s.values, value)
+			s.mean += value
+		}
+	}
+
+	s.mean /= float64(len(s.values))
+
+	if s.mean != 0 {
+		for _, value := range s.values {
+			s.variation = max(s.variation, math.Abs(value-s.mean)/s.mean*100)
+		}
+	}
+
+	return s
+}
+
+// quantile interpolates linearly between the sorted values.
+func quantile(sorted []float64, q float64) float64 {
+	position := q * float64(len(sorted)-1)
+	lower := int(position)
+
+	if lower+1 >= len(sorted) {
+		return sorted[lower]
+	}
+
+	return sorted[lower] + (position-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
+
+// compare formats a table for every unit with the summaries of the baseline
+// and the current results and the change between them.
+func compare(baseline, current *results) string {
+	var builder strings.Builder
+
+	units := slices.Clone(baseline.units)
+	for _, unit := range current.units {
+		if !slices.Contains(units, unit) {
+			units = append(units, unit)
+		}
+	}
+
+	names := slices.Clone(baseline.names)
+	for _, name := range current.names {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	for i, unit := range units {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+
+		metric, ok := metrics[unit]
+		if !ok {
+			metric = unit
+		}
+
+		writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(writer, "name\told %s\tnew %s\tdelta\n", metric, metric)
+
+		var oldMeans, newMeans []float64
+
+		for _, name := range names {
+			oldSamples, newSamples := baseline.samples[unit][name], current.samples[unit][name]
+			if len(oldSamples) == 0 && len(newSamples) == 0 {
+				continue
+			}
+
+			row := []string{name, "", "", ""}
+
+			var oldSummary, newSummary summary
+
+			if len(oldSamples) > 0 {
+				oldSummary = summarize(oldSamples)
+				row[1] = formatSummary(oldSummary, unit)
+			}
+
+			if len(newSamples) > 0 {
+				newSummary = summarize(newSamples)
+				row[2] = formatSummary(newSummary, unit)
+			}
+
+			if len(oldSamples) > 0 && len(newSamples) > 0 {
+				row[3] = delta(oldSummary, newSummary)
+				oldMeans = append(oldMeans, oldSummary.mean)
+				newMeans = append(newMeans, newSummary.mean)
+			}
+
+			fmt.Fprintln(writer, strings.Join(row, "\t"))
+		}
+
+		if oldMean, newMean, ok := geomeans(oldMeans, newMeans); ok && len(oldMeans) > 1 {
+			fmt.Fprintf(writer, "[Geo mean]\t%s\t%s\t%+.2f%%\n",
+				formatValue(oldMean, unit), formatValue(newMean, unit), (newMean/oldMean-1)*100)
+		}
+
+		writer.Flush()
+	}
+
+	// cells of missing results are padded by the tab writer
+	lines := strings.Split(builder.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// delta is the change of the means if it is significant, else a tilde.
+func delta(old, current summary) string {
+	if allEqual(old.values, current.values) {
+		return "~     (all equal)"
+	}
+
+	p := mannWhitneyU(old.values, current.values)
+	samples := fmt.Sprintf("(p=%.3f n=%d+%d)", p, len(old.values), len(current.values))
+
+	if p >= significance {
+		return "~     " + samples
+	}
+
+	return fmt.Sprintf("%+.2f%%  %s", (current.mean/old.mean-1)*100, samples)
+}
+
+func allEqual(old, current []float64) bool {
+	for _, value := range append(slices.Clone(old), current...) {
+		if value != old[0] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// geomeans returns the geometric means, which are undefined for zero values.
+func geomeans(old, current []float64) (float64, float64, bool) {
+	var oldSum, currentSum float64
+
+	for i := range old {
+		if old[i] <= 0 || current[i] <= 0 {
+			return 0, 0, false
+		}
+
+		oldSum += math.Log(old[i])
+		currentSum += math.Log(current[i])
+	}
+
+	return math.Exp(oldSum / float64(len(old))), math.Exp(currentSum / float64(len(current))), len(old) > 0
+}
+
+func formatSummary(s summary, unit string) string {
+	return fmt.Sprintf("%s ± %.0f%%", formatValue(s.mean, unit), s.variation)
+}
+
+// formatValue scales the value to three significant digits with the prefix of the unit.
+func formatValue(value float64, unit string) string {
+	prefixes := []string{"", "k", "M", "G", "T"}
+
+	switch unit {
+	case "ns/op":
+		prefixes = []string{"ns", "µs", "ms", "s"}
+	case "B/op":
+		prefixes = []string{"B", "kB", "MB", "GB", "TB"}
+	}
+
+	prefix := 0
+	for math.Abs(value) >= 999.5 && prefix < len(prefixes)-1 {
+		value /= 1000
+		prefix++
+	}
+
+	switch {
+	case math.Abs(value) >= 99.95:
+		return fmt.Sprintf("%.0f%s", value, prefixes[prefix])
+	case math.Abs(value) >= 9.995:
+		return fmt.Sprintf("%.1f%s", value, prefixes[prefix])
+	}
+
+	return fmt.Sprintf("%.2f%s", value, prefixes[prefix])
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const baselineOutput = `goos: linux
+pkg: github.com/StevenCyb/goeval/pkg/expr
+BenchmarkSuite/Small/Tree-8   	 1000	      100 ns/op	       8 B/op	       1 allocs/op
+BenchmarkSuite/Small/Tree-8   	 1000	      101 ns/op	       8 B/op	       1 allocs/op
+BenchmarkSuite/Small/Tree-8   	 1000	      102 ns/op	       8 B/op	       1 allocs/op
+BenchmarkSuite/Small/Tree-8   	 1000	      103 ns/op	       8 B/op	       1 allocs/op
+BenchmarkSuite/Small/Tree-8   	 1000	      104 ns/op	       8 B/op	       1 allocs/op
+BenchmarkSuite/Large/Tree-8   	 1000	     2000 ns/op	       0 B/op	       0 allocs/op
+BenchmarkSuite/Large/Tree-8   	 1000	     2100 ns/op	       0 B/op	       0 allocs/op
+BenchmarkSuite/Removed-8      	 1000	     5000 ns/op
+--- FAIL: BenchmarkSuite/Broken
+PASS
+`
+
+const currentOutput = `BenchmarkSuite/Small/Tree-2   	 1000	      50 ns/op	       8 B/op	       1 allocs/op
+BenchmarkSuite/Small/Tree-2   	 1000	      51 ns/op	       8 B/op	       1 allocs/op
+BenchmarkSuite/Small/Tree-2   	 1000	      52 ns/op	       8 B/op	       1 allocs/op
+BenchmarkSuite/Small/Tree-2   	 1000	      53 ns/op	       8 B/op	       1 allocs/op
+BenchmarkSuite/Small/Tree-2   	 1000	      54 ns/op	       8 B/op	       1 allocs/op
+BenchmarkSuite/Large/Tree-2   	 1000	     2050 ns/op	       0 B/op	       0 allocs/op
+BenchmarkSuite/Large/Tree-2   	 1000	     1990 ns/op	       0 B/op	       0 allocs/op
+BenchmarkSuite/Added-2        	 1000	     1500000 ns/op
+`
+
+func Test_Parse(t *testing.T) {
+	t.Parallel()
+
+	r, err := parse(strings.NewReader(baselineOutput))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ns/op", "B/op", "allocs/op"}, r.units)
+	assert.Equal(t, []string{"Suite/Small/Tree", "Suite/Large/Tree", "Suite/Removed"}, r.names)
+	assert.Equal(t, []float64{100, 101, 102, 103, 104}, r.samples["ns/op"]["Suite/Small/Tree"])
+	assert.Equal(t, []float64{0, 0}, r.samples["allocs/op"]["Suite/Large/Tree"])
+
+	_, err = parse(strings.NewReader("BenchmarkX 10 abc ns/op"))
+	require.Error(t, err)
+}
+
+func Test_Compare(t *testing.T) {
+	t.Parallel()
+
+	baseline, err := parse(strings.NewReader(baselineOutput))
+	require.NoError(t, err)
+
+	current, err := parse(strings.NewReader(currentOutput))
+	require.NoError(t, err)
+
+	assert.Equal(t, `name              old time/op  new time/op  delta
+Suite/Small/Tree  102ns ± 2%   52.0ns ± 4%  -49.02%  (p=0.008 n=5+5)
+Suite/Large/Tree  2.05µs ± 2%  2.02µs ± 1%  ~     (p=0.667 n=2+2)
+Suite/Removed     5.00µs ± 0%
+Suite/Added                    1.50ms ± 0%
+[Geo mean]        457ns        324ns        -29.12%
+
+name              old alloc/op  new alloc/op  delta
+Suite/Small/Tree  8.00B ± 0%    8.00B ± 0%    ~     (all equal)
+Suite/Large/Tree  0.00B ± 0%    0.00B ± 0%    ~     (all equal)
+
+name              old allocs/op  new allocs/op  delta
+Suite/Small/Tree  1.00 ± 0%      1.00 ± 0%      ~     (all equal)
+Suite/Large/Tree  0.00 ± 0%      0.00 ± 0%      ~     (all equal)
+`, compare(baseline, current))
+}
+
+func Test_MannWhitneyU(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name string
+		x, y []float64
+		p    float64
+	}{
+		{name: "Separated", x: []float64{1, 2, 3, 4, 5}, y: []float64{6, 7, 8, 9, 10}, p: 2.0 / 252},
+		{name: "Separated_Reversed", x: []float64{6, 7, 8, 9, 10}, y: []float64{1, 2, 3, 4, 5}, p: 2.0 / 252},
+		{name: "Interleaved", x: []float64{1, 3, 5}, y: []float64{2, 4, 6}, p: 0.7},
+		{name: "Single", x: []float64{1}, y: []float64{2}, p: 1},
+		{name: "Ties", x: []float64{1, 1, 1}, y: []float64{2, 2, 2}, p: 0.0469},
+		{name: "All_Equal", x: []float64{1, 1}, y: []float64{1, 1}, p: 1},
+	}
+
+	for _, tc := range tcs {
+		tcRef := tc
+
+		t.Run(tcRef.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.InDelta(t, tcRef.p, mannWhitneyU(tcRef.x, tcRef.y), 0.0001)
+		})
+	}
+}
+
+func Test_Summarize(t *testing.T) {
+	t.Parallel()
+
+	s := summarize([]float64{10, 11, 9, 10, 100})
+	assert.Equal(t, []float64{9, 10, 10, 11}, s.values)
+	assert.InDelta(t, 10, s.mean, 0.0001)
+	assert.InDelta(t, 10, s.variation, 0.0001)
+}
+
+func Test_FormatValue(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "1.23µs", formatValue(1234, "ns/op"))
+	assert.Equal(t, "999ns", formatValue(999, "ns/op"))
+	assert.Equal(t, "1.00µs", formatValue(999.9, "ns/op"))
+	assert.Equal(t, "12.3s", formatValue(12.3e9, "ns/op"))
+	assert.Equal(t, "179kB", formatValue(179216, "B/op"))
+	assert.Equal(t, "2.22k", formatValue(2218, "allocs/op"))
+	assert.Equal(t, "5.00", formatValue(5, "allocs/op"))
+}
@@ -0,0 +1,37 @@
+// Command benchcmp compares the output of `go test -bench` with a baseline
+// and prints the changes of every metric like benchstat.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+func main() {
+	if len(os.Args) != 3 {
+		log.Fatal("usage: benchcmp <baseline file> <new file>")
+	}
+
+	baseline, err := parseFile(os.Args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	current, err := parseFile(os.Args[2])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Print(compare(baseline, current))
+}
+
+func parseFile(name string) (*results, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parse(file)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"math"
+	"slices"
+)
+
+// exactLimit is the largest number of pairs the exact distribution is computed for.
+const exactLimit = 2500
+
+// mannWhitneyU returns the two-sided p-value of the Mann-Whitney U-test, the
+// probability that samples of the same distribution differ at least as much.
+// Without ties it is exact, else it is approximated by the normal distribution.
+func mannWhitneyU(x, y []float64) float64 {
+	m, n := len(x), len(y)
+	u, ties := statisticU(x, y)
+
+	if !ties && m*n <= exactLimit {
+		counts := uDistribution(m, n)
+		total, below, above := 0.0, 0.0, 0.0
+
+		for value, count := range counts {
+			total += count
+
+			if float64(value) <= u {
+				below += count
+			}
+
+			if float64(value) >= u {
+				above += count
+			}
+		}
+
+		return min(1, 2*min(below, above)/total)
+	}
+
+	size := float64(m + n)
+	correction := 0.0
+
+	for _, count := range tieCounts(append(slices.Clone(x), y...)) {
+		correction += float64(count*count*count - count)
+	}
+
+	variance := float64(m*n) / 12 * (size + 1 - correction/(size*(size-1)))
+	if variance <= 0 {
+		return 1
+	}
+
+	z := max(0, math.Abs(u-float64(m*n)/2)-0.5) / math.Sqrt(variance)
+
+	return math.Erfc(z / math.Sqrt2)
+}
+
+// statisticU counts the pairs with the value of x greater than the one of y,
+// ties count half.
+func statisticU(x, y []float64) (float64, bool) {
+	u, ties := 0.0, false
+
+	for _, a := range x {
+		for _, b := range y {
+			switch {
+			case a > b:
+				u++
+			case a == b:
+				u += 0.5
+				ties = true
+			}
+		}
+	}
+
+	return u, ties || len(tieCounts(x)) < len(x) || len(tieCounts(y)) < len(y)
+}
+
+// tieCounts returns the number of occurrences of every distinct value.
+func tieCounts(values []float64) []int {
+	sorted := slices.Sorted(slices.Values(values))
+	counts := []int{}
+
+	for i, value := range sorted {
+		if i > 0 && value == sorted[i-1] {
+			counts[len(counts)-1]++
+		} else {
+			counts = append(counts, 1)
+		}
+	}
+
+	return counts
+}
+
+// uDistribution returns the number of orderings of m and n distinct values for every U.
+// The greatest value is either from x and greater than all n values of y, or from y.
+func uDistribution(m, n int) []float64 {
+	// counts[i][j] is the distribution of i values of x and j values of y
+	counts := make([][][]float64, m+1)
+
+	for i := range counts {
+		counts[i] = make([][]float64, n+1)
+
+		for j := range counts[i] {
+			counts[i][j] = make([]float64, i*j+1)
+
+			if i == 0 || j == 0 {
+				counts[i][j][0] = 1
+
+				continue
+			}
+
+			for u := range counts[i][j] {
+				if u >= j && u-j < len(counts[i-1][j]) {
+					counts[i][j][u] += counts[i-1][j][u-j]
+				}
+
+				if u < len(counts[i][j-1]) {
+					counts[i][j][u] += counts[i][j-1][u]
+				}
+			}
+		}
+	}
+
+	return counts[m][n]
+}
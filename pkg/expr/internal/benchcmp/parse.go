@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// procsSuffix is the GOMAXPROCS suffix of benchmark names, it is removed
+// so results of machines with a different number of CPUs are comparable.
+var procsSuffix = regexp.MustCompile(`-\d+$`)
+
+// results are the samples of the benchmarks by unit and name.
+type results struct {
+	// units and names in order of their first appearance.
+	units   []string
+	names   []string
+	samples map[string]map[string][]float64
+}
+
+func newResults() *results {
+	return &results{samples: map[string]map[string][]float64{}}
+}
+
+func (r *results) add(unit, name string, value float64) {
+	if _, ok := r.samples[unit]; !ok {
+		r.units = append(r.units, unit)
+		r.samples[unit] = map[string][]float64{}
+	}
+
+	if !slices.Contains(r.names, name) {
+		r.names = append(r.names, name)
+	}
+
+	r.samples[unit][name] = append(r.samples[unit][name], value)
+}
+
+// parse reads the benchmark lines of `go test -bench` output and ignores other lines.
+// A line is the name, the iterations and pairs of value and unit.
+func parse(reader io.Reader) (*results, error) {
+	r := newResults()
+	scanner := bufio.NewScanner(reader)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || len(fields)%2 != 0 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue
+		}
+
+		name := procsSuffix.ReplaceAllString(strings.TrimPrefix(fields[0], "Benchmark"), "")
+
+		for i := 2; i < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+
+			r.add(fields[i+1], name, value)
+		}
+	}
+
+	return r, scanner.Err()
+}
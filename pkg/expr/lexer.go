@@ -0,0 +1,211 @@
+package expr
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// tokenType is the type of a token, its name is used in errors.
+type tokenType string
+
+func (t tokenType) String() string {
+	return string(t)
+}
+
+// lexer scans the source into tokens without allocating. The tokens are
+// the same as if matched by the regular expressions of the grammar.
+type lexer struct {
+	source string
+	offset int
+}
+
+// next returns the type and the byte offsets of the next token
+// without whitespace and comments, or endType at the end of the source.
+func (l *lexer) next() (tokenType, int, int) {
+	for {
+		typ, start, end := l.scan()
+		if typ != skipType && typ != commentType {
+			return typ, start, end
+		}
+	}
+}
+
+// scan returns the type and the byte offsets of the next token.
+func (l *lexer) scan() (tokenType, int, int) {
+	start := l.offset
+	if start >= len(l.source) {
+		return endType, start, start
+	}
+
+	typ, end := l.token(start)
+	l.offset = end
+
+	return typ, start, end
+}
+
+// token matches the token at start in the order of the grammar.
+func (l *lexer) token(start int) (tokenType, int) {
+	switch char := l.source[start]; {
+	case isSpace(char):
+		end := start + 1
+		for end < len(l.source) && isSpace(l.source[end]) {
+			end++
+		}
+
+		return skipType, end
+	case char == '(':
+		return contextStartType, start + 1
+	case char == ')':
+		return contextEndType, start + 1
+	case char == ',':
+		return separatorType, start + 1
+	case strings.HasPrefix(l.source[start:], "/*"):
+		return l.comment(start)
+	}
+
+	if end := l.operation(start); end > start {
+		return operationType, end
+	}
+
+	if end := l.number(start); end > start {
+		return numberType, end
+	}
+
+	if end := l.identifier(start); end > start {
+		return identifierType, end
+	}
+
+	if end := l.text(start); end > start {
+		return textType, end
+	}
+
+	_, size := utf8.DecodeRuneInString(l.source[start:])
+
+	return invalidType, start + size
+}
+
+// comment matches up to the first "*/", an unterminated comment
+// is an invalid "/*" token.
+func (l *lexer) comment(start int) (tokenType, int) {
+	if end := strings.Index(l.source[start+2:], "*/"); end != -1 {
+		return commentType, start + end + 4
+	}
+
+	return invalidType, start + 2
+}
+
+// operation matches the longest operation.
+func (l *lexer) operation(start int) int {
+	if start+1 < len(l.source) {
+		switch l.source[start : start+2] {
+		case "**", "//", "&&", "||", "&^", "<<", ">>", "==", "!=", "<=", ">=":
+			return start + 2
+		}
+	}
+
+	if strings.IndexByte("-+/*%&|^<>!", l.source[start]) != -1 {
+		return start + 1
+	}
+
+	return start
+}
+
+// number greedily matches everything that looks like a number,
+// it is validated by scanNumber.
+func (l *lexer) number(start int) int {
+	rest := l.source[start:]
+
+	if (strings.HasPrefix(rest, "Inf") || strings.HasPrefix(rest, "NaN")) &&
+		(len(rest) == 3 || !isWordCharacter(rest[3])) {
+		return start + 3
+	}
+
+	if len(rest) > 1 && rest[0] == '0' && (rest[1]|0x20) == 'x' {
+		return l.numberTail(start+2, 'p')
+	}
+
+	index := start
+	if rest[0] == '.' {
+		index++
+	}
+
+	if index < len(l.source) && isDigit(l.source[index], intBase) {
+		return l.numberTail(index+1, 'e')
+	}
+
+	return start
+}
+
+// numberTail matches word characters, dots and signed exponents.
+func (l *lexer) numberTail(index int, exponent byte) int {
+	for index < len(l.source) {
+		char := l.source[index]
+
+		switch {
+		case (char|0x20) == exponent && index+1 < len(l.source) &&
+			(l.source[index+1] == '+' || l.source[index+1] == '-'):
+			index += 2
+		case isWordCharacter(char) || char == '.':
+			index++
+		default:
+			return index
+		}
+	}
+
+	return index
+}
+
+func (l *lexer) identifier(start int) int {
+	if char := l.source[start]; char != '_' && !isLetter(char) {
+		return start
+	}
+
+	end := start + 1
+	for end < len(l.source) && isWordCharacter(l.source[end]) {
+		end++
+	}
+
+	return end
+}
+
+// text matches quoted text up to the closing quote, an escaped line break
+// or missing closing quote is no text. Raw text can not be escaped.
+func (l *lexer) text(start int) int {
+	quote := l.source[start]
+
+	switch quote {
+	case '`':
+		if end := strings.IndexByte(l.source[start+1:], '`'); end != -1 {
+			return start + end + 2
+		}
+	case '"', '\'':
+		for index := start + 1; index < len(l.source); index++ {
+			switch l.source[index] {
+			case quote:
+				return index + 1
+			case '\\':
+				if index+1 == len(l.source) || l.source[index+1] == '\n' {
+					return start
+				}
+
+				index++
+			}
+		}
+	}
+
+	return start
+}
+
+// isSpace matches the ASCII whitespace of `\s`.
+func isSpace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\n' || char == '\f' || char == '\r'
+}
+
+func isLetter(char byte) bool {
+	return (char|0x20) >= 'a' && (char|0x20) <= 'z'
+}
+
+// isWordCharacter matches `\w`.
+func isWordCharacter(char byte) bool {
+	return char == '_' || isLetter(char) || isDigit(char, intBase)
+}
@@ -0,0 +1,170 @@
+package expr
+
+import (
+	"math/rand"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// regexSpecs are the token regexes of the grammar the lexer has to match like.
+var regexSpecs = []struct {
+	regex     *regexp.Regexp
+	tokenType tokenType
+}{
+	{regexp.MustCompile(`^\s+`), skipType},
+	{regexp.MustCompile(`^\(`), contextStartType},
+	{regexp.MustCompile(`^\)`), contextEndType},
+	{regexp.MustCompile(`^,`), separatorType},
+	{regexp.MustCompile(`^/\*[\s\S]*?\*/`), commentType},
+	{regexp.MustCompile(`^/\*`), invalidType},
+	{regexp.MustCompile(`^(\*\*|//|&&|\|\||&\^|<<|>>|==|!=|<=|>=|[-+/*%&|^<>!])`), operationType},
+	{regexp.MustCompile(`^((Inf|NaN)\b|0[xX]([pP][-+]|[\w.])*|\.?\d([eE][-+]|[\w.])*)`), numberType},
+	{regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*`), identifierType},
+	{regexp.MustCompile("^(\"(?:[^\"\\\\]|\\\\.)*\"|'(?:[^'\\\\]|\\\\.)*'|`[^`]*`)"), textType},
+	{regexp.MustCompile(`^.`), invalidType},
+}
+
+type lexedToken struct {
+	Type  tokenType
+	Value string
+}
+
+func regexTokens(source string) []lexedToken {
+	tokens := []lexedToken{}
+
+	for offset := 0; offset < len(source); {
+		for _, spec := range regexSpecs {
+			if match := spec.regex.FindString(source[offset:]); match != "" {
+				tokens = append(tokens, lexedToken{Type: spec.tokenType, Value: match})
+				offset += len(match)
+
+				break
+			}
+		}
+	}
+
+	return tokens
+}
+
+func lexerTokens(source string) []lexedToken {
+	tokens := []lexedToken{}
+	l := lexer{source: source}
+
+	for {
+		typ, start, end := l.scan()
+		if typ == endType {
+			return tokens
+		}
+
+		tokens = append(tokens, lexedToken{Type: typ, Value: source[start:end]})
+	}
+}
+
+func TestLexer(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		source   string
+		expected []lexedToken
+	}{
+		{"", []lexedToken{}},
+		{" \t\n1", []lexedToken{{skipType, " \t\n"}, {numberType, "1"}}},
+		{"f(a, b)", []lexedToken{
+			{identifierType, "f"}, {contextStartType, "("}, {identifierType, "a"}, {separatorType, ","},
+			{skipType, " "}, {identifierType, "b"}, {contextEndType, ")"},
+		}},
+		{"&&&^**//!", []lexedToken{
+			{operationType, "&&"}, {operationType, "&^"}, {operationType, "**"},
+			{operationType, "//"}, {operationType, "!"},
+		}},
+		{"1e-3+0x1p+2-.5", []lexedToken{
+			{numberType, "1e-3"}, {operationType, "+"}, {numberType, "0x1p+2"},
+			{operationType, "-"}, {numberType, ".5"},
+		}},
+		{"1_000.5.5abc", []lexedToken{{numberType, "1_000.5.5abc"}}},
+		{"Inf InfX NaN", []lexedToken{
+			{numberType, "Inf"}, {skipType, " "}, {identifierType, "InfX"},
+			{skipType, " "}, {numberType, "NaN"},
+		}},
+		{`"a\"b" 'c\'d' ` + "`e\\`", []lexedToken{
+			{textType, `"a\"b"`}, {skipType, " "}, {textType, `'c\'d'`},
+			{skipType, " "}, {textType, "`e\\`"},
+		}},
+		{`"abc`, []lexedToken{{invalidType, `"`}, {identifierType, "abc"}}},
+		{"'a\\\nb'", []lexedToken{
+			{invalidType, "'"}, {identifierType, "a"}, {invalidType, "\\"},
+			{skipType, "\n"}, {identifierType, "b"}, {invalidType, "'"},
+		}},
+		{"1/**/ /* a * / b */2", []lexedToken{
+			{numberType, "1"}, {commentType, "/**/"}, {skipType, " "},
+			{commentType, "/* a * / b */"}, {numberType, "2"},
+		}},
+		{"1 /* a", []lexedToken{
+			{numberType, "1"}, {skipType, " "}, {invalidType, "/*"}, {skipType, " "}, {identifierType, "a"},
+		}},
+		{"§.", []lexedToken{{invalidType, "§"}, {invalidType, "."}}},
+		{"\xff1", []lexedToken{{invalidType, "\xff"}, {numberType, "1"}}},
+	}
+
+	for _, tc := range tcs {
+		tcRef := tc
+		t.Run(tcRef.source, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tcRef.expected, lexerTokens(tcRef.source))
+			assert.Equal(t, regexTokens(tcRef.source), lexerTokens(tcRef.source))
+		})
+	}
+}
+
+func TestLexer_Like_Regex(t *testing.T) {
+	t.Parallel()
+
+	alphabet := []string{
+		" ", "\t", "\n", "(", ")", ",", "*", "/", "&", "|", "^", "<", ">", "=", "!", "-", "+", "%",
+		"0", "1", "9", "x", "X", "e", "E", "p", "P", ".", "_", "I", "n", "f", "N", "a",
+		"\"", "'", "`", "\\", "§", "\xff",
+	}
+	random := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 10000; i++ {
+		source := ""
+		for j := random.Intn(16); j >= 0; j-- {
+			source += alphabet[random.Intn(len(alphabet))]
+		}
+
+		require.Equal(t, regexTokens(source), lexerTokens(source), "source %q", source)
+	}
+}
+
+func FuzzLexer(f *testing.F) {
+	f.Add("(1 + 2) * 3 > 4 && 'a' != 'b'")
+	f.Add("0x1p-2 1e+3 .5 Inf NaN `raw` \"a\\\"b\"")
+
+	f.Fuzz(func(t *testing.T, source string) {
+		require.Equal(t, regexTokens(source), lexerTokens(source))
+	})
+}
+
+const benchmarkLexerSource = "(price * 1_000 + 0x1F) / 3 >= limit && !(country == 'DE' || name != \"x\\ty\") || 2 ** 10 > 1e3"
+
+func BenchmarkLexer(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		l := lexer{source: benchmarkLexerSource}
+		for typ, _, _ := l.next(); typ != endType; typ, _, _ = l.next() {
+		}
+	}
+}
+
+// BenchmarkLexer_Regex measures the replaced regular expression tokenizer for comparison.
+func BenchmarkLexer_Regex(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		regexTokens(benchmarkLexerSource)
+	}
+}
@@ -0,0 +1,102 @@
+package expr
+
+import "github.com/StevenCyb/goeval/pkg/errs"
+
+// DefaultMaxDepth is the maximum nesting of expressions unless set by WithMaxDepth.
+const DefaultMaxDepth = 1000
+
+// DefaultMaxIntegerBits is the maximum size of integers promoted to *big.Int
+// unless set by WithMaxIntegerBits.
+const DefaultMaxIntegerBits = 1 << 20
+
+// costs of the nodes for the static cost estimate.
+const (
+	operationCost = 1
+	variableCost  = 1
+	callCost      = 10
+)
+
+// estimateCost sums up the costs of the nodes.
+func estimateCost(root *Node) int {
+	cost := 0
+
+	Walk(root, func(n *Node, _ int) bool {
+		switch n.Kind {
+		case BinaryNode, UnaryNode:
+			cost += operationCost
+		case IdentifierNode:
+			cost += variableCost
+		case CallNode:
+			cost += callCost
+		}
+
+		return true
+	})
+
+	return cost
+}
+
+// checkDepth fails at the first node nested deeper than the maximum depth.
+// Unlike the recursion of the parser, it also catches long chains of operations.
+func (p *parser) checkDepth(root *Node) {
+	if p.config.maxDepth <= 0 {
+		return
+	}
+
+	failed := false
+
+	Walk(root, func(n *Node, depth int) bool {
+		if !failed && depth > p.config.maxDepth {
+			p.fail(errs.NewErrorAtSpan(errs.ErrMaxDepth, n.Span))
+
+			failed = true
+		}
+
+		return !failed
+	})
+}
+
+// tooDeep fails from the lookahead to the end and skips the remaining tokens,
+// which can not be parsed without nesting deeper.
+func (p *parser) tooDeep() *Node {
+	start := len(p.source)
+	if p.lookahead.Type != endType {
+		start = p.lookahead.span.Start.Offset
+	}
+
+	node := p.fail(errs.NewErrorAtSpan(errs.ErrMaxDepth, p.span(start, len(p.source))))
+
+	for p.lookahead.Type != endType {
+		p.advance()
+	}
+
+	return node
+}
+
+// step counts the evaluation of a node against the maximum steps.
+func (e *evaluation) step(n *Node) error {
+	e.steps++
+	if e.config.maxSteps > 0 && e.steps > e.config.maxSteps {
+		return errs.NewErrorAtSpan(errs.ErrMaxSteps, n.Span)
+	}
+
+	return nil
+}
+
+// fitsIntegerBits reports whether an integer of the bits is within the maximum.
+func (c *config) fitsIntegerBits(bits int) bool {
+	return c.maxIntegerBits <= 0 || bits <= c.maxIntegerBits
+}
+
+// checkString fails on texts longer than the maximum length.
+func (e *evaluation) checkString(n *Node, value tagged) error {
+	if value.kind != stringKind || e.config.maxStringLength <= 0 {
+		return nil
+	}
+
+	if text := value.ref.(string); len(text) > e.config.maxStringLength {
+		return errs.NewErrorAtSpan(errs.ErrStringTooLong, n.Span)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package expr
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Limits(t *testing.T) {
+	t.Parallel()
+
+	repeat := func(_ context.Context, args ...interface{}) (interface{}, error) {
+		return strings.Repeat("a", int(args[0].(float64))), nil
+	}
+
+	tcs := []struct {
+		name       string
+		expression string
+		env        Env
+		opts       []Option
+		start      int
+		end        int
+		err        error
+	}{
+		{name: "Source_Length", expression: "1 + 2", opts: []Option{WithMaxSourceLength(4)}, start: -1, err: errs.ErrSourceTooLong},
+		{name: "Depth_Nesting", expression: "1 + ((2))", opts: []Option{WithMaxDepth(2)}, start: 5, end: 9, err: errs.ErrMaxDepth},
+		{name: "Depth_Unary", expression: "!!!true", opts: []Option{WithMaxDepth(2)}, start: 2, end: 7, err: errs.ErrMaxDepth},
+		{name: "Depth_Chain", expression: "1 + 2 + 3 + 4", opts: []Option{WithMaxDepth(3)}, start: 0, end: 1, err: errs.ErrMaxDepth},
+		{name: "Steps", expression: "1 + 2 + 3 + 4", opts: []Option{WithMaxSteps(2)}, start: 2, end: 3, err: errs.ErrMaxSteps},
+		{name: "String_Literal", expression: "'abc' == 'abcd'", opts: []Option{WithMaxStringLength(3)}, start: 9, end: 15, err: errs.ErrStringTooLong},
+		{name: "String_Variable", expression: "name == 'a'", env: Env{"name": "abcd"}, opts: []Option{WithMaxStringLength(3)}, start: 0, end: 4, err: errs.ErrStringTooLong},
+		{name: "String_Function", expression: "repeat(2) == repeat(4)", opts: []Option{WithMaxStringLength(3)}, start: 13, end: 19, err: errs.ErrStringTooLong},
+		{name: "Cost", expression: "repeat(1) == a", opts: []Option{WithMaxCost(11)}, start: 0, end: 14, err: errs.ErrMaxCost},
+		{name: "Integer_Bits_Power", expression: "3 ** 40", opts: []Option{WithIntegers(OverflowPromote), WithMaxIntegerBits(64)}, start: 2, end: 4, err: errs.ErrIntegerOverflow},
+		{name: "Integer_Bits_Shift", expression: "1 << 64", opts: []Option{WithIntegers(OverflowPromote), WithMaxIntegerBits(64)}, start: 2, end: 4, err: errs.ErrIntegerOverflow},
+		{name: "Integer_Bits_Product", expression: "a * a", env: Env{"a": int64(1) << 40}, opts: []Option{WithIntegers(OverflowPromote), WithMaxIntegerBits(64)}, start: 2, end: 3, err: errs.ErrIntegerOverflow},
+	}
+
+	for _, backend := range backends {
+		for _, tc := range tcs {
+			tcRef, backendRef := tc, backend
+
+			t.Run(backendRef.name+"/"+tcRef.name, func(t *testing.T) {
+				t.Parallel()
+
+				opts := append([]Option{WithFunction("repeat", repeat)}, backendRef.opts...)
+				opts = append(opts, tcRef.opts...)
+
+				var err error
+				if program, compileErr := Compile(tcRef.expression, opts...); compileErr != nil {
+					err = compileErr
+				} else {
+					err = program.Eval(tcRef.env).Error
+				}
+
+				if tcRef.start == -1 {
+					assert.Equal(t, tcRef.err, err)
+
+					return
+				}
+
+				assert.Equal(t, errorAt(tcRef.expression, tcRef.start, tcRef.end, tcRef.err), err)
+			})
+		}
+	}
+}
+
+func Test_Limits_Within(t *testing.T) {
+	t.Parallel()
+
+	program, err := Compile("1 + ((2)) == a", WithEnv(Env{"a": 3}), WithMaxSourceLength(14), WithMaxDepth(4),
+		WithMaxSteps(4), WithMaxStringLength(3), WithMaxCost(4))
+	require.NoError(t, err)
+
+	result := program.Eval(nil)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, true, result.Value)
+	assert.Equal(t, 3, program.Cost())
+}
+
+func Test_Limits_Integer_Bits(t *testing.T) {
+	t.Parallel()
+
+	limits := []Option{WithIntegers(OverflowPromote), WithMaxSteps(10), WithMaxCost(10),
+		WithMaxSourceLength(30), WithMaxStringLength(10)}
+
+	assert.ErrorIs(t, EvalWithOptions("1 << 300000000", limits...).Error, errs.ErrIntegerOverflow)
+	assert.ErrorIs(t, EvalWithOptions("2 ** 30000000", limits...).Error, errs.ErrIntegerOverflow)
+
+	result := EvalWithOptions("3 ** 40", append(limits, WithMaxIntegerBits(64))...)
+	assert.ErrorIs(t, result.Error, errs.ErrIntegerOverflow)
+
+	result = EvalWithOptions("3 ** 40 > 1 << 63", append(limits, WithMaxIntegerBits(128))...)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, true, result.Value)
+
+	value, err := EvalWithOptions("1 << 2000000", WithIntegers(OverflowPromote), WithMaxIntegerBits(0)).BigInt()
+	require.NoError(t, err)
+	assert.Equal(t, 2000001, value.BitLen())
+}
+
+func Test_Limits_Default_Depth(t *testing.T) {
+	t.Parallel()
+
+	nested := strings.Repeat("(", 100_000) + "1" + strings.Repeat(")", 100_000)
+	result := Eval(nested)
+	assert.ErrorIs(t, result.Error, errs.ErrMaxDepth)
+	assert.Equal(t, DefaultMaxDepth, result.Error.(errs.Error).Span().Start.Offset)
+
+	chain := "1" + strings.Repeat(" + 1", DefaultMaxDepth)
+	assert.ErrorIs(t, Eval(chain).Error, errs.ErrMaxDepth)
+	assert.Equal(t, float64(DefaultMaxDepth+1), EvalWithOptions(chain, WithMaxDepth(0)).Value)
+}
+
+func Test_Walk(t *testing.T) {
+	t.Parallel()
+
+	root, err := Parse("f(a, 1) + 2 * !b", WithFunction("f", nil))
+	require.NoError(t, err)
+
+	var visited []string
+
+	Walk(root, func(n *Node, depth int) bool {
+		visited = append(visited, strings.Repeat(".", depth)+n.Operator+n.Name)
+
+		return n.Kind != UnaryNode
+	})
+
+	assert.Equal(t, []string{".+", "..f", "...a", "...", "..*", "...", "...!"}, visited)
+}
@@ -0,0 +1,142 @@
+package expr
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+)
+
+// NodeKind is the kind of a node of the expression tree.
+type NodeKind int
+
+const (
+	// LiteralNode is a number, text or boolean.
+	LiteralNode NodeKind = iota
+	// BinaryNode is an operation with two operands.
+	BinaryNode
+	// UnaryNode is an operation with one operand.
+	UnaryNode
+	// ErrorNode replaces a part of the source that could not be parsed.
+	ErrorNode
+	// IdentifierNode is a variable of the environment.
+	IdentifierNode
+	// CallNode is a call of a registered function.
+	CallNode
+)
+
+// Node of the expression tree.
+type Node struct {
+	Kind NodeKind
+	// Operator of binary and unary nodes.
+	Operator string
+	// Value of literal nodes.
+	Value interface{}
+	// Name of identifier and call nodes.
+	Name string
+	// Args of call nodes.
+	Args []*Node
+	// Left is the operand of unary nodes.
+	Left  *Node
+	Right *Node
+	// Span of the literal, the operator, the name or the broken part of the source.
+	Span errs.Span
+	// Err is the located error of error nodes.
+	Err error
+}
+
+// Walk calls fn for the node and its descendants in depth-first order with
+// their depth, starting at 1. The children of a node are skipped if fn
+// returns false. It does not recurse, so deep trees do not exhaust the stack.
+func Walk(root *Node, fn func(n *Node, depth int) bool) {
+	type entry struct {
+		node  *Node
+		depth int
+	}
+
+	stack := []entry{{node: root, depth: 1}}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if current.node == nil || !fn(current.node, current.depth) {
+			continue
+		}
+
+		for i := len(current.node.Args) - 1; i >= 0; i-- {
+			stack = append(stack, entry{node: current.node.Args[i], depth: current.depth + 1})
+		}
+
+		stack = append(stack,
+			entry{node: current.node.Right, depth: current.depth + 1},
+			entry{node: current.node.Left, depth: current.depth + 1})
+	}
+}
+
+// String returns the tree as expression with every operation in parentheses,
+// e.g. to compare it before and after WithOptimize. Error nodes are `<error>`.
+func (n *Node) String() string {
+	var builder strings.Builder
+
+	n.write(&builder)
+
+	return builder.String()
+}
+
+func (n *Node) write(builder *strings.Builder) {
+	switch n.Kind {
+	case LiteralNode:
+		builder.WriteString(formatValue(n.Value))
+	case IdentifierNode:
+		builder.WriteString(n.Name)
+	case CallNode:
+		builder.WriteString(n.Name)
+		builder.WriteByte('(')
+
+		for i, arg := range n.Args {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+
+			arg.write(builder)
+		}
+
+		builder.WriteByte(')')
+	case UnaryNode:
+		builder.WriteString(n.Operator)
+		n.Left.write(builder)
+	case BinaryNode:
+		builder.WriteByte('(')
+		n.Left.write(builder)
+		builder.WriteString(" " + n.Operator + " ")
+		n.Right.write(builder)
+		builder.WriteByte(')')
+	default:
+		builder.WriteString("<error>")
+	}
+}
+
+// formatValue formats the value of a literal as it is written in an expression.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, intBase)
+	case *big.Int:
+		return v.String()
+	case float64:
+		if math.IsInf(v, 1) {
+			return "Inf"
+		}
+
+		return strconv.FormatFloat(v, 'g', -1, float64Size)
+	}
+
+	return "<invalid>"
+}
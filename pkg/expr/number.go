@@ -0,0 +1,169 @@
+package expr
+
+import (
+	"strings"
+)
+
+const (
+	binaryBase      = 2
+	octalBase       = 8
+	hexadecimalBase = 16
+)
+
+// numberLiteral is a validated Go-style numeric literal.
+type numberLiteral struct {
+	// digits without underscores and base prefix.
+	digits string
+	base   int
+	float  bool
+}
+
+// scanNumber validates the literal and returns the index of the
+// first malformed character or -1 if the literal is well-formed.
+// Unlike Go, leading zeros do not denote an octal number.
+func scanNumber(literal string) (numberLiteral, int) {
+	number := numberLiteral{base: intBase}
+	index := 0
+
+	if len(literal) > 1 && literal[0] == '0' {
+		switch literal[1] {
+		case 'x', 'X':
+			number.base = hexadecimalBase
+		case 'o', 'O':
+			number.base = octalBase
+		case 'b', 'B':
+			number.base = binaryBase
+		}
+
+		if number.base != intBase {
+			index = 2
+		}
+	}
+
+	index, count, invalid := scanDigits(literal, index, number.base, number.base != intBase)
+	if invalid != -1 {
+		return number, invalid
+	}
+
+	if index < len(literal) && literal[index] == '.' {
+		if number.base != intBase && number.base != hexadecimalBase {
+			return number, index
+		}
+
+		number.float = true
+
+		var fraction int
+
+		index, fraction, invalid = scanDigits(literal, index+1, number.base, false)
+		if invalid != -1 {
+			return number, invalid
+		}
+
+		count += fraction
+	}
+
+	if count == 0 {
+		return number, min(index, len(literal)-1)
+	}
+
+	index, invalid = scanExponent(literal, index, &number)
+	if invalid != -1 {
+		return number, invalid
+	}
+
+	if index < len(literal) {
+		return number, index
+	}
+
+	if number.base != intBase {
+		number.digits = strings.ReplaceAll(literal[2:], "_", "")
+	} else {
+		number.digits = strings.ReplaceAll(literal, "_", "")
+	}
+
+	return number, -1
+}
+
+// scanExponent scans an optional exponent, which is mandatory for hexadecimal floats.
+func scanExponent(literal string, index int, number *numberLiteral) (int, int) {
+	exponent := byte('e')
+	if number.base == hexadecimalBase {
+		exponent = 'p'
+	}
+
+	if index >= len(literal) || (literal[index]|0x20) != exponent {
+		if number.base == hexadecimalBase && number.float {
+			return index, min(index, len(literal)-1)
+		}
+
+		return index, -1
+	}
+
+	if number.base != intBase && number.base != hexadecimalBase {
+		return index, index
+	}
+
+	number.float = true
+	index++
+
+	if index < len(literal) && (literal[index] == '+' || literal[index] == '-') {
+		index++
+	}
+
+	index, count, invalid := scanDigits(literal, index, intBase, false)
+	if invalid != -1 {
+		return index, invalid
+	}
+
+	if count == 0 {
+		return index, min(index, len(literal)-1)
+	}
+
+	return index, -1
+}
+
+// scanDigits scans digits of the base starting at index. Underscores may only
+// separate digits or follow the base prefix. It returns the index after the digits,
+// the number of digits and the index of a misplaced underscore or -1.
+func scanDigits(literal string, index, base int, afterPrefix bool) (int, int, int) {
+	count := 0
+	previousDigit := afterPrefix
+
+	for ; index < len(literal); index++ {
+		char := literal[index]
+
+		if char == '_' {
+			if !previousDigit {
+				return index, count, index
+			}
+
+			previousDigit = false
+
+			continue
+		}
+
+		if !isDigit(char, base) {
+			break
+		}
+
+		previousDigit = true
+		count++
+	}
+
+	if index > 0 && literal[index-1] == '_' {
+		return index, count, index - 1
+	}
+
+	return index, count, -1
+}
+
+func isDigit(char byte, base int) bool {
+	switch {
+	case char >= '0' && char <= '9':
+		return int(char-'0') < base
+	case (char|0x20) >= 'a' && (char|0x20) <= 'f':
+		return base == hexadecimalBase
+	}
+
+	return false
+}
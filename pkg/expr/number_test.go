@@ -0,0 +1,61 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ScanNumber(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		literal string
+		expect  numberLiteral
+		invalid int
+	}{
+		{literal: "42", expect: numberLiteral{digits: "42", base: 10}, invalid: -1},
+		{literal: "007", expect: numberLiteral{digits: "007", base: 10}, invalid: -1},
+		{literal: "1_000_000", expect: numberLiteral{digits: "1000000", base: 10}, invalid: -1},
+		{literal: "1.5", expect: numberLiteral{digits: "1.5", base: 10, float: true}, invalid: -1},
+		{literal: ".5", expect: numberLiteral{digits: ".5", base: 10, float: true}, invalid: -1},
+		{literal: "5.", expect: numberLiteral{digits: "5.", base: 10, float: true}, invalid: -1},
+		{literal: "1e6", expect: numberLiteral{digits: "1e6", base: 10, float: true}, invalid: -1},
+		{literal: "1.5E-3", expect: numberLiteral{digits: "1.5E-3", base: 10, float: true}, invalid: -1},
+		{literal: "0xFF", expect: numberLiteral{digits: "FF", base: 16}, invalid: -1},
+		{literal: "0x_FF", expect: numberLiteral{digits: "FF", base: 16}, invalid: -1},
+		{literal: "0x1.8p1", expect: numberLiteral{digits: "1.8p1", base: 16, float: true}, invalid: -1},
+		{literal: "0o755", expect: numberLiteral{digits: "755", base: 8}, invalid: -1},
+		{literal: "0b1010", expect: numberLiteral{digits: "1010", base: 2}, invalid: -1},
+		{literal: "1__0", invalid: 2},
+		{literal: "1_", invalid: 1},
+		{literal: "1_.5", invalid: 1},
+		{literal: "1._5", invalid: 2},
+		{literal: "1.5.5", invalid: 3},
+		{literal: "1e", invalid: 1},
+		{literal: "1e+", invalid: 2},
+		{literal: "12abc", invalid: 2},
+		{literal: "0x", invalid: 1},
+		{literal: "0xG", invalid: 2},
+		{literal: "0x1.8", invalid: 4},
+		{literal: "0o78", invalid: 3},
+		{literal: "0b102", invalid: 4},
+		{literal: "0b1.1", invalid: 3},
+		{literal: "0o1e5", invalid: 3},
+	}
+
+	for _, tc := range tcs {
+		tcRef := tc
+
+		t.Run(tcRef.literal, func(t *testing.T) {
+			t.Parallel()
+
+			actual, invalid := scanNumber(tcRef.literal)
+			assert.Equal(t, tcRef.invalid, invalid)
+
+			if tcRef.invalid == -1 {
+				assert.Equal(t, tcRef.expect, actual)
+			}
+		})
+	}
+}
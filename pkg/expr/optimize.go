@@ -0,0 +1,122 @@
+package expr
+
+import (
+	"context"
+	"errors"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+)
+
+// optimizer folds constant sub-trees into literals and removes dead branches
+// of logical operations. Sub-trees are folded by evaluating them, so the values
+// are identical. Sub-trees that fail are kept to fail on evaluation.
+// Folding is limited like one evaluation, it stops once the maximum steps
+// of the whole tree are evaluated or the context is done.
+type optimizer struct {
+	config *config
+	// evaluation folds all sub-trees, so the steps are counted for the tree.
+	evaluation *evaluation
+	stopped    bool
+}
+
+func optimize(ctx context.Context, root *Node, cfg *config) *Node {
+	folding := *cfg
+	// panics of functions are recovered when folding, to be raised on evaluation
+	folding.panics = false
+
+	o := &optimizer{
+		config:     &folding,
+		evaluation: &evaluation{ctx: ctx, config: &folding},
+	}
+
+	return o.optimize(root)
+}
+
+func (o *optimizer) optimize(n *Node) *Node {
+	switch n.Kind {
+	case CallNode:
+		constant := !o.config.impure[n.Name]
+
+		for i, arg := range n.Args {
+			n.Args[i] = o.optimize(arg)
+			constant = constant && n.Args[i].Kind == LiteralNode
+		}
+
+		if constant {
+			return o.fold(n)
+		}
+	case UnaryNode:
+		if n.Left = o.optimize(n.Left); n.Left.Kind == LiteralNode {
+			return o.fold(n)
+		}
+	case BinaryNode:
+		n.Left = o.optimize(n.Left)
+		n.Right = o.optimize(n.Right)
+
+		if operators[n.Operator].tokenType == logicalOperationType {
+			return o.logicalOperation(n)
+		}
+
+		if n.Left.Kind == LiteralNode && n.Right.Kind == LiteralNode {
+			return o.fold(n)
+		}
+	}
+
+	return n
+}
+
+// logicalOperation removes the right side if the constant left side short-circuits,
+// and constant sides that do not change the boolean value of the other side.
+func (o *optimizer) logicalOperation(n *Node) *Node {
+	short := n.Operator == "||"
+
+	switch {
+	case n.Left.Kind == LiteralNode && (convertBool(n.Left.Value) == short || n.Right.Kind == LiteralNode):
+		return o.fold(n)
+	case n.Left.Kind == LiteralNode && isBoolean(n.Right):
+		return n.Right
+	case n.Right.Kind == LiteralNode && convertBool(n.Right.Value) != short && isBoolean(n.Left):
+		return n.Left
+	}
+
+	return n
+}
+
+// fold evaluates the node into a literal, unless it fails or folding stopped.
+func (o *optimizer) fold(n *Node) *Node {
+	if o.stopped {
+		return n
+	}
+
+	value, err := o.evaluation.evaluate(n)
+	if err != nil {
+		o.stopped = errors.Is(err, errs.ErrMaxSteps) || o.evaluation.ctx.Err() != nil
+
+		return n
+	}
+
+	return &Node{
+		Kind:  LiteralNode,
+		Value: value.boxed(),
+		Span:  n.Span,
+	}
+}
+
+// isBoolean reports if the node always evaluates to a bool.
+func isBoolean(n *Node) bool {
+	switch n.Kind {
+	case UnaryNode:
+		return true
+	case BinaryNode:
+		switch operators[n.Operator].tokenType {
+		case logicalOperationType, comparisonOperationType:
+			return true
+		}
+	case LiteralNode:
+		_, ok := n.Value.(bool)
+
+		return ok
+	}
+
+	return false
+}
@@ -0,0 +1,143 @@
+package expr
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Optimize(t *testing.T) {
+	t.Parallel()
+
+	fail := func(context.Context, ...interface{}) (interface{}, error) {
+		return nil, errors.New("fail")
+	}
+	one := func(context.Context, ...interface{}) (interface{}, error) {
+		return 1, nil
+	}
+	env := Env{"x": 3, "flag": true, "text": "abc"}
+
+	tcs := []struct {
+		name       string
+		expression string
+		optimized  string
+	}{
+		{name: "Arithmetic", expression: "(1+2)*3 > x", optimized: "(9 > x)"},
+		{name: "Comparison_And", expression: "'a' == 'a' && flag", optimized: "(true && flag)"},
+		{name: "Comparison_And_Boolean", expression: "'a' == 'a' && x > 1", optimized: "(x > 1)"},
+		{name: "Dead_And", expression: "1 > 2 && x / 0", optimized: "false"},
+		{name: "Dead_Or", expression: "!false || unknown", optimized: "true"},
+		{name: "Neutral_Right", expression: "x > 1 || 1 > 2", optimized: "(x > 1)"},
+		{name: "Short_Circuit_Needs_Left", expression: "x && false", optimized: "(x && false)"},
+		{name: "Not", expression: "!(2 > 1) || x == 3", optimized: "(x == 3)"},
+		{name: "Partial", expression: "x + 2 * 3", optimized: "(x + 6)"},
+		{name: "Left_Associative_Not_Folded", expression: "x + 2 + 3", optimized: "((x + 2) + 3)"},
+		{name: "Pure_Function", expression: "length(concat('a', 'bc')) + x", optimized: "(3 + x)"},
+		{name: "Function_Variable_Argument", expression: "length(text) * (2 + 2)", optimized: "(length(text) * 4)"},
+		{name: "Impure_Function", expression: "counter() + 1 * 2", optimized: "(counter() + 2)"},
+		{name: "Failing_Function_Kept", expression: "x > 0 || fail(1 + 1)", optimized: "((x > 0) || fail(2))"},
+		{name: "Division_By_Zero_Kept", expression: "x + 1 / 0", optimized: "(x + (1 / 0))"},
+		{name: "Literals", expression: "0x10 + .5 == Inf", optimized: "false"},
+		{name: "Text", expression: "concat('a\\n', text)", optimized: "concat(\"a\\n\", text)"},
+	}
+
+	for _, tc := range tcs {
+		tcRef := tc
+
+		t.Run(tcRef.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := []Option{
+				WithFunction("length", length), WithFunction("concat", concat),
+				WithFunction("fail", fail), WithImpureFunction("counter", one),
+			}
+
+			program, err := Compile(tcRef.expression, opts...)
+			require.NoError(t, err)
+
+			optimized, err := Compile(tcRef.expression, append(opts, WithOptimize())...)
+			require.NoError(t, err)
+			assert.Equal(t, tcRef.optimized, optimized.String())
+
+			expected := program.Eval(env)
+			actual := optimized.Eval(env)
+
+			if expected.Error != nil {
+				assert.Equal(t, errs.CodeOf(expected.Error), errs.CodeOf(actual.Error))
+				assert.Equal(t, expected.Error.Error(), actual.Error.Error())
+
+				return
+			}
+
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func Test_Optimize_Impure_Called_On_Evaluation(t *testing.T) {
+	t.Parallel()
+
+	var calls int64
+
+	counter := func(context.Context, ...interface{}) (interface{}, error) {
+		return atomic.AddInt64(&calls, 1), nil
+	}
+
+	program, err := Compile("counter() > 0", WithImpureFunction("counter", counter), WithOptimize())
+	require.NoError(t, err)
+
+	program.Eval(nil)
+	program.Eval(nil)
+	assert.Equal(t, int64(2), atomic.LoadInt64(&calls))
+
+	// registering it again as pure folds the call
+	program, err = Compile("counter() > 0", WithImpureFunction("counter", counter), WithFunction("counter", counter), WithOptimize())
+	require.NoError(t, err)
+	assert.Equal(t, "true", program.String())
+	assert.Equal(t, int64(3), atomic.LoadInt64(&calls))
+}
+
+func Test_Optimize_Panic(t *testing.T) {
+	t.Parallel()
+
+	explode := func(_ context.Context, args ...interface{}) (interface{}, error) {
+		return args[1], nil
+	}
+
+	program, err := Compile("explode(1)", WithFunction("explode", explode), WithoutPanicRecovery(), WithOptimize())
+	require.NoError(t, err)
+	assert.Equal(t, "explode(1)", program.String())
+	assert.Panics(t, func() { program.Eval(nil) })
+}
+
+func Test_Optimize_Limits(t *testing.T) {
+	t.Parallel()
+
+	// the steps are shared by all folds, once exceeded folding stops
+	program, err := Compile("1 + 2 + 3 + 4 > x || 2 * 3 > x", WithMaxSteps(2), WithOptimize())
+	require.NoError(t, err)
+	assert.Equal(t, "(((6 + 4) > x) || ((2 * 3) > x))", program.String())
+
+	program, err = Compile("2 ** 30000000 > x", WithIntegers(OverflowPromote), WithOptimize())
+	require.NoError(t, err)
+	assert.Equal(t, "((2 ** 30000000) > x)", program.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	program, err = compile(ctx, "1 + 2 > x", newConfig(WithOptimize()))
+	require.NoError(t, err)
+	assert.Equal(t, "((1 + 2) > x)", program.String())
+}
+
+func Test_Node_String(t *testing.T) {
+	t.Parallel()
+
+	root, _ := Parse("!(a >= 1_000) && f(1e3, 'x', `y`, TRUE, NaN) || (1 + )", WithFunction("f", concat))
+	assert.Equal(t, `((!(a >= 1000) && f(1000, "x", "y", true, NaN)) || (1 + <error>))`, root.String())
+}
@@ -0,0 +1,209 @@
+package expr
+
+import "maps"
+
+// Overflow defines how an int64 overflow is handled
+// when integer arithmetic is enabled.
+type Overflow int
+
+const (
+	// OverflowError returns a positioned error on int64 overflow.
+	OverflowError Overflow = iota
+	// OverflowPromote transparently promotes the value to *big.Int.
+	OverflowPromote
+)
+
+// Option configures the evaluation of an expression.
+type Option func(*config)
+
+type config struct {
+	settings
+	env Env
+	// declared is set if the variables are known when compiling.
+	declared  bool
+	functions map[string]Function
+	// impure functions are not folded by the optimizer.
+	impure   map[string]bool
+	resolver Resolver
+}
+
+// settings are the comparable part of the config, to identify cached programs.
+type settings struct {
+	integers      bool
+	overflow      Overflow
+	wordOperators bool
+	// panics are not recovered if set.
+	panics bool
+	// bytecode compiles programs for the virtual machine.
+	bytecode bool
+	optimize bool
+	// limits, zero is unlimited.
+	maxSourceLength int
+	maxDepth        int
+	maxSteps        int
+	maxStringLength int
+	maxCost         int
+	maxIntegerBits  int
+}
+
+func newConfig(opts ...Option) *config {
+	cfg := &config{settings: settings{maxDepth: DefaultMaxDepth, maxIntegerBits: DefaultMaxIntegerBits}}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
+
+// clone copies the config and its maps, so later changes of the
+// environment passed to WithEnv do not affect compiled programs.
+func (c *config) clone() *config {
+	clone := *c
+	clone.env = maps.Clone(c.env)
+	clone.functions = maps.Clone(c.functions)
+	clone.impure = maps.Clone(c.impure)
+
+	return &clone
+}
+
+// WithIntegers enables integer arithmetic. Numbers without a fraction are
+// evaluated as int64 and the given overflow behavior is applied on
+// `+`, `-`, `*`, `%` and `**`.
+func WithIntegers(overflow Overflow) Option {
+	return func(c *config) {
+		c.integers = true
+		c.overflow = overflow
+	}
+}
+
+// WithWordOperators enables the word operators `and`, `or`, `not` and `is`
+// (case insensitive) as alternative to `&&`, `||`, `!` and `==`.
+// Like in SQL `not` binds looser than comparisons and `is not` is `!=`.
+func WithWordOperators() Option {
+	return func(c *config) {
+		c.wordOperators = true
+	}
+}
+
+// WithEnv declares the variables of the environment, unknown identifiers are
+// reported when compiling. EvalWithOptions evaluates with the environment,
+// for Compile it is the default of Program.Eval. The environment is copied,
+// later changes are not seen by the program.
+func WithEnv(env Env) Option {
+	return func(c *config) {
+		c.env = env
+		c.declared = true
+	}
+}
+
+// WithFunction registers the function to be called by name. The function is
+// considered pure, so WithOptimize calls it once when compiling if all arguments
+// are constant. Functions with side effects or changing results must be
+// registered by WithImpureFunction.
+func WithFunction(name string, function Function) Option {
+	return func(c *config) {
+		if c.functions == nil {
+			c.functions = map[string]Function{}
+		}
+
+		c.functions[name] = function
+		delete(c.impure, name)
+	}
+}
+
+// WithImpureFunction registers the function like WithFunction,
+// but it is always called on evaluation.
+func WithImpureFunction(name string, function Function) Option {
+	return func(c *config) {
+		WithFunction(name, function)(c)
+
+		if c.impure == nil {
+			c.impure = map[string]bool{}
+		}
+
+		c.impure[name] = true
+	}
+}
+
+// WithResolver resolves variables that are not in the environment,
+// e.g. to load them lazily. Unknown identifiers are then reported on evaluation.
+func WithResolver(resolver Resolver) Option {
+	return func(c *config) {
+		c.resolver = resolver
+	}
+}
+
+// WithoutPanicRecovery lets panics of functions and resolvers crash,
+// instead of returning them as errs.PanicError.
+func WithoutPanicRecovery() Option {
+	return func(c *config) {
+		c.panics = true
+	}
+}
+
+// WithBytecode compiles the program to bytecode, which is executed by a stack
+// based virtual machine instead of walking the tree. It is faster for programs
+// evaluated many times and the results are identical.
+func WithBytecode() Option {
+	return func(c *config) {
+		c.bytecode = true
+	}
+}
+
+// WithOptimize folds constant parts of the expression into literals when
+// compiling, e.g. `(1 + 2) * 3 > x` into `9 > x`, and removes dead branches of
+// `&&` and `||`. The results are identical, but fewer steps are evaluated.
+// Parts that fail, e.g. `1 / 0`, are kept to fail on evaluation. Folding is
+// limited like one evaluation and stops once WithMaxSteps are exceeded.
+func WithOptimize() Option {
+	return func(c *config) {
+		c.optimize = true
+	}
+}
+
+// WithMaxSourceLength limits the length of the expression in bytes.
+func WithMaxSourceLength(length int) Option {
+	return func(c *config) {
+		c.maxSourceLength = length
+	}
+}
+
+// WithMaxDepth limits the nesting of the expression, which is DefaultMaxDepth
+// by default. Zero removes the limit, the parser may then exhaust the stack.
+func WithMaxDepth(depth int) Option {
+	return func(c *config) {
+		c.maxDepth = depth
+	}
+}
+
+// WithMaxSteps limits the operations, variables and calls evaluated per evaluation.
+func WithMaxSteps(steps int) Option {
+	return func(c *config) {
+		c.maxSteps = steps
+	}
+}
+
+// WithMaxStringLength limits the length in bytes of the texts of the expression,
+// the environment and the results of functions.
+func WithMaxStringLength(length int) Option {
+	return func(c *config) {
+		c.maxStringLength = length
+	}
+}
+
+// WithMaxCost rejects expressions on compilation,
+// whose estimated cost exceeds the maximum, see Program.Cost.
+func WithMaxCost(cost int) Option {
+	return func(c *config) {
+		c.maxCost = cost
+	}
+}
+
+// WithMaxIntegerBits limits the size in bits of integers promoted to *big.Int by
+// OverflowPromote, which is DefaultMaxIntegerBits by default. Larger results are
+// reported as integer overflow. Zero removes the limit.
+func WithMaxIntegerBits(bits int) Option {
+	return func(c *config) {
+		c.maxIntegerBits = bits
+	}
+}
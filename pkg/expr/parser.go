@@ -1,370 +1,666 @@
 package expr
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
+	"math/big"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/StevenCyb/goeval/pkg/errs"
-	"github.com/StevenCyb/gotokenizer/pkg/tokenizer"
 )
 
-var (
-	skipType                tokenizer.Type = "SKIP"
-	contextStartType        tokenizer.Type = "CONTEXT_START"
-	contextEndType          tokenizer.Type = "CONTEXT_END"
-	arithmeticOperationType tokenizer.Type = "ARITHMETIC_OPERATION"
-	comparisonOperationType tokenizer.Type = "COMPARISON_OPERATION"
-	logicalOperationType    tokenizer.Type = "LOGICAL_OPERATION"
-	numberType              tokenizer.Type = "NUMBER"
-	boolType                tokenizer.Type = "BOOL"
-	textType                tokenizer.Type = "TEXT"
+const (
+	// endType is the type of the empty token at the end of the source.
+	endType                 tokenType = ""
+	skipType                tokenType = "SKIP"
+	commentType             tokenType = "COMMENT"
+	contextStartType        tokenType = "CONTEXT_START"
+	contextEndType          tokenType = "CONTEXT_END"
+	operationType           tokenType = "OPERATION"
+	arithmeticOperationType tokenType = "ARITHMETIC_OPERATION"
+	bitwiseOperationType    tokenType = "BITWISE_OPERATION"
+	comparisonOperationType tokenType = "COMPARISON_OPERATION"
+	logicalOperationType    tokenType = "LOGICAL_OPERATION"
+	numberType              tokenType = "NUMBER"
+	boolType                tokenType = "BOOL"
+	textType                tokenType = "TEXT"
+	identifierType          tokenType = "IDENTIFIER"
+	separatorType           tokenType = "SEPARATOR"
+	invalidType             tokenType = "INVALID"
+)
 
+var (
 	intBase     = 10
-	int64Size   = 64
 	float64Size = 64
+
+	// operators with Go-equivalent precedence, `**` binds tighter than all of them.
+	operators = map[string]operator{
+		"||": {tokenType: logicalOperationType, precedence: 1},
+		"&&": {tokenType: logicalOperationType, precedence: 2},
+		"==": {tokenType: comparisonOperationType, precedence: 3, rightAssociative: true},
+		"!=": {tokenType: comparisonOperationType, precedence: 3, rightAssociative: true},
+		"<":  {tokenType: comparisonOperationType, precedence: 3, rightAssociative: true},
+		"<=": {tokenType: comparisonOperationType, precedence: 3, rightAssociative: true},
+		">":  {tokenType: comparisonOperationType, precedence: 3, rightAssociative: true},
+		">=": {tokenType: comparisonOperationType, precedence: 3, rightAssociative: true},
+		"+":  {tokenType: arithmeticOperationType, precedence: 4},
+		"-":  {tokenType: arithmeticOperationType, precedence: 4},
+		"|":  {tokenType: bitwiseOperationType, precedence: 4},
+		"^":  {tokenType: bitwiseOperationType, precedence: 4},
+		"*":  {tokenType: arithmeticOperationType, precedence: 5},
+		"/":  {tokenType: arithmeticOperationType, precedence: 5},
+		"//": {tokenType: arithmeticOperationType, precedence: 5},
+		"%":  {tokenType: arithmeticOperationType, precedence: 5},
+		"<<": {tokenType: bitwiseOperationType, precedence: 5},
+		">>": {tokenType: bitwiseOperationType, precedence: 5},
+		"&":  {tokenType: bitwiseOperationType, precedence: 5},
+		"&^": {tokenType: bitwiseOperationType, precedence: 5},
+		"**": {tokenType: arithmeticOperationType, precedence: 6, rightAssociative: true},
+	}
+
+	// keywords are whole words matched case-insensitive.
+	keywords = map[string]keyword{
+		"true":  {tokenType: boolType, value: "true"},
+		"false": {tokenType: boolType, value: "false"},
+	}
+
+	// wordOperators are keywords enabled by WithWordOperators.
+	wordOperators = map[string]keyword{
+		"and": {tokenType: logicalOperationType, value: "&&"},
+		"or":  {tokenType: logicalOperationType, value: "||"},
+		"not": {tokenType: logicalOperationType, value: "!"},
+		"is":  {tokenType: comparisonOperationType, value: "=="},
+	}
 )
 
+const (
+	lowestPrecedence = 1
+	// `!` binds tighter than all binary operations except `**`.
+	unaryPrecedence = 6
+	// `not` binds looser than comparisons like in SQL.
+	wordNotPrecedence = 3
+)
+
+// keyword a word is tokenized as.
+type keyword struct {
+	tokenType tokenType
+	value     string
+}
+
+// operator describes how a binary operation binds.
+type operator struct {
+	tokenType        tokenType
+	precedence       int
+	rightAssociative bool
+}
+
+// token is a token of the lexer with the span it covers.
+type token struct {
+	Type  tokenType
+	Value string
+	span  errs.Span
+	// keyword is the lowercase word if the token is a keyword.
+	keyword string
+}
+
 func Eval(format string, a ...any) Result {
-	expression := strings.TrimSpace(fmt.Sprintf(format, a...))
-	if expression == "" {
+	return EvalWithOptions(fmt.Sprintf(format, a...))
+}
+
+// EvalWithOptions evaluates the expression with the given options.
+// Unlike Eval, the expression is not used as format string.
+// Compiled programs are cached unless options with an environment,
+// functions or a resolver are given, see SetCacheSize.
+func EvalWithOptions(expression string, opts ...Option) Result {
+	return EvalContext(context.Background(), expression, opts...)
+}
+
+// EvalContext is like EvalWithOptions, but stops once the context is done,
+// see Program.EvalContext.
+func EvalContext(ctx context.Context, expression string, opts ...Option) Result {
+	cfg := newConfig(opts...)
+	// without WithEnv there are no variables
+	cfg.declared = true
+
+	program, err := cache.compile(ctx, expression, cfg)
+	if err != nil {
 		return Result{
-			Error: errs.ErrEmptyExpression,
+			Error: err,
 		}
 	}
 
-	return newParser(expression).Parse()
+	return program.EvalContext(ctx, cfg.env)
 }
 
-// LL(2) parser for the following grammar:
+// Parse parses the expression into a tree without evaluating it.
+// On errors the tree is partial with an ErrorNode for every broken part
+// and the error is an errs.List of all errors if there are more than one.
+func Parse(expression string, opts ...Option) (*Node, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, errs.ErrEmptyExpression
+	}
+
+	p := newParser(expression, newConfig(opts...))
+	root := p.parse()
+
+	return root, p.errors.Err()
+}
+
+// Precedence climbing parser for the following grammar,
+// operations are listed from lowest to highest precedence.
+// The tokens are scanned by a hand-written lexer that matches like the listed regexes:
 /*
-<EXPRESSION>            ::= <NUMBER>
-													| <TEXT>
-													| <BOOL>
-													| <CONTEXT_EXPRESSION>
-													| <ARITHMETIC_EXPRESSION>
-													| <LOGICAL_EXPRESSION>
-													| <COMPARISON_EXPRESSION>
-
-<ARITHMETIC_EXPRESSION> ::= <EXPRESSION> <ARITHMETIC_OPERATION> <EXPRESSION>
-<LOGICAL_EXPRESSION> 		::= <EXPRESSION> <LOGICAL_OPERATION> <EXPRESSION>
-<COMPARISON_EXPRESSION>	::= <EXPRESSION> <COMPARISON_OPERATION> <EXPRESSION>
-<CONTEXT_EXPRESSION>		::= <CONTEXT_START> <EXPRESSION> <CONTEXT_END>
-													| <CONTEXT_START> <EXPRESSION> <CONTEXT_END> <ARITHMETIC_OPERATION> <EXPRESSION>
-													| <CONTEXT_START> <EXPRESSION> <CONTEXT_END> <LOGICAL_EXPRESSION> <EXPRESSION>
-													| <CONTEXT_START> <EXPRESSION> <CONTEXT_END> <COMPARISON_EXPRESSION> <EXPRESSION>
+<EXPRESSION>            ::= <OR_EXPRESSION>
+<OR_EXPRESSION>         ::= <AND_EXPRESSION> { "||" <AND_EXPRESSION> }
+<AND_EXPRESSION>        ::= <COMPARISON> { "&&" <COMPARISON> }
+<COMPARISON>            ::= <SUM> [ ("==" | "!=" | "<" | "<=" | ">" | ">=") <COMPARISON> ]
+<SUM>                   ::= <PRODUCT> { ("+" | "-" | "|" | "^") <PRODUCT> }
+<PRODUCT>               ::= <POWER> { ("*" | "/" | "//" | "%" | "<<" | ">>" | "&" | "&^") <POWER> }
+<POWER>                 ::= <UNARY> [ "**" <POWER> ]
+<UNARY>                 ::= "!" <POWER> | "not" <COMPARISON> | <PRIMARY>
+<PRIMARY>               ::= <NUMBER> | <TEXT> | <BOOL> | <IDENTIFIER> | <CALL> | <CONTEXT_START> <EXPRESSION> <CONTEXT_END>
+<CALL>                  ::= <IDENTIFIER> <CONTEXT_START> [ <EXPRESSION> { <SEPARATOR> <EXPRESSION> } ] <CONTEXT_END>
 
 <SKIP>                  ::= ^\s+
+<COMMENT>               ::= ^/\*[\s\S]*?\*\/
 <CONTEXT_START>         ::= ^\(
 <CONTEXT_END>           ::= ^\)
-<LOGICAL_OPERATION>     ::= ^(&&|\|\|)
-<COMPARISON_OPERATION>  ::= ^(==|!=|<=?|>=?)
-<ARITHMETIC_OPERATION>  ::= ^(\+|-|\*|\/|%)
-<NUMBER>                ::= ^\d+(\.\d+)?
-<BOOL>                  ::= ^(true|false)
-<TEXT>                  ::= ^("[^"]*"|'[^"]*')
+<SEPARATOR>             ::= ^,
+<OPERATION>             ::= ^(\*\*|//|&&|\|\||&\^|<<|>>|==|!=|<=|>=|[-+/*%&|^<>!])
+<NUMBER>                ::= ^((Inf|NaN)\b|0[xX]([pP][-+]|[\w.])*|\.?\d([eE][-+]|[\w.])*)
+<IDENTIFIER>            ::= ^[A-Za-z_][A-Za-z0-9_]*
+<TEXT>                  ::= ^("(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|`[^`]*`)
+<INVALID>               ::= ^.
+
+Identifiers are looked up in the keywords, so "true", "TRUE" and "True" are a <BOOL>
+while "trueValue" is a variable. With word operators "and", "or", "not" and "is" are
+tokenized like "&&", "||", "!" and "==", "is not" is "!=".
+
+The <NUMBER> token greedily matches everything that looks like a number, it is
+validated afterwards to report malformed literals at the exact character.
+
+All operations share a single <OPERATION> token matched longest first, so e.g. "&&"
+can never be tokenized as two "&". The token type is then refined to
+ARITHMETIC_OPERATION, BITWISE_OPERATION, COMPARISON_OPERATION or LOGICAL_OPERATION.
+
+Whitespace and comments are skipped, an unterminated "/*" is an <INVALID> token.
+Any other character is an <INVALID> token, so the parser can report it and go on.
+On an error the parser records it, puts an error node into the tree and
+synchronizes at the next operation, separator or closing parenthesis to find further errors.
 */
 type parser struct {
-	tokenizer  *tokenizer.Tokenizer
-	lookahead1 *tokenizer.Token
-	lookahead2 *tokenizer.Token
+	config    *config
+	source    string
+	lexer     lexer
+	lookahead token
+	// cursor is the position of the last span, to compute lines and columns incrementally.
+	cursor errs.Position
+	errors errs.List
+	// errorEnd is the end offset of the last error, to suppress follow-up errors.
+	errorEnd int
+	// depth of the recursion, to not exhaust the stack on deep nesting.
+	depth int
 }
 
-// Create a new LL(2) parser for the given expression.
-func newParser(expression string) *parser {
+// Create a new parser for the given expression.
+func newParser(expression string, cfg *config) *parser {
 	return &parser{
-		tokenizer: tokenizer.New(
-			expression,
-			skipType,
-			[]*tokenizer.Spec{
-				tokenizer.NewSpec(`^\s+`, skipType),
-				tokenizer.NewSpec(`^\(`, contextStartType),
-				tokenizer.NewSpec(`^\)`, contextEndType),
-				tokenizer.NewSpec(`^(\+|-|\*|\/|%)`, arithmeticOperationType),
-				tokenizer.NewSpec(`^(==|!=|<=?|>=?)`, comparisonOperationType),
-				tokenizer.NewSpec(`^(&&|\|\|)`, logicalOperationType),
-				tokenizer.NewSpec(`^\d+(\.\d+)?`, numberType),
-				tokenizer.NewSpec(`^(true|false)`, boolType),
-				tokenizer.NewSpec(`^("(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*')`, textType),
-			}),
+		config:   cfg,
+		source:   expression,
+		cursor:   errs.Position{Line: 1, Column: 1},
+		errorEnd: -1,
+		lexer:    lexer{source: expression},
 	}
 }
 
-// eat return a token with expected type.
-func (p *parser) eat(tokenType tokenizer.Type) (*tokenizer.Token, error) {
-	token := p.lookahead1
-	p.lookahead1 = p.lookahead2
+// next reads the next token into the lookahead.
+func (p *parser) next() {
+	typ, start, end := p.lexer.next()
+	if typ == endType {
+		p.lookahead = token{}
 
-	if token == nil {
-		return nil, errs.NewErrUnexpectedInputEnd(tokenType.String())
+		return
 	}
 
-	if token.Type != tokenType {
-		return nil, errs.NewErrorAtPosition(
-			errs.NewErrUnexpectedTokenType(token.Type.String(), tokenType.String()),
-			p.tokenizer.GetCursorPosition(),
-		)
+	p.lookahead = token{
+		Type:  typ,
+		Value: p.source[start:end],
+		span:  p.span(start, end),
 	}
 
-	var err error
-	p.lookahead2, err = p.tokenizer.GetNextToken()
-
-	return token, err
+	switch typ {
+	case operationType:
+		if p.lookahead.Value == "!" {
+			p.lookahead.Type = logicalOperationType
+		} else {
+			p.lookahead.Type = operators[p.lookahead.Value].tokenType
+		}
+	case identifierType:
+		p.lookupKeyword(&p.lookahead)
+	}
 }
 
-func (p *parser) Parse() Result {
-	var err error
-
-	p.lookahead1, err = p.tokenizer.GetNextToken()
-	if err != nil {
-		return Result{
-			Error: err,
-		}
+// lookupKeyword turns the identifier into a keyword token if it is one.
+func (p *parser) lookupKeyword(token *token) {
+	word, keyword, ok := findKeyword(keywords, token.Value)
+	if !ok && p.config.wordOperators {
+		word, keyword, ok = findKeyword(wordOperators, token.Value)
 	}
-	p.lookahead2, err = p.tokenizer.GetNextToken()
-	if err != nil {
-		return Result{
-			Error: err,
-		}
+
+	if !ok {
+		return
 	}
 
-	value, err := p.expression(nil)
+	token.keyword = word
+	token.Type = keyword.tokenType
 
-	return Result{
-		Value: value,
-		Error: err,
+	if keyword.tokenType != boolType {
+		token.Value = keyword.value
 	}
 }
 
-func (p *parser) expression(leftValue interface{}) (interface{}, error) {
-	if p.lookahead1 == nil {
-		return nil, errs.NewErrorAtPosition(
-			errs.NewErrUnexpectedInputEnd("expression"),
-			p.tokenizer.GetCursorPosition())
+// findKeyword looks up the word case-insensitive without allocating.
+func findKeyword(keywords map[string]keyword, value string) (string, keyword, bool) {
+	for word, keyword := range keywords {
+		if len(word) == len(value) && strings.EqualFold(word, value) {
+			return word, keyword, true
+		}
 	}
 
-	if p.lookahead1.Type == contextStartType {
-		return p.contextExpression()
+	return "", keyword{}, false
+}
+
+// span returns the span between the byte offsets,
+// which must not be before the previous span.
+func (p *parser) span(start, end int) errs.Span {
+	p.cursor = p.cursor.Advance(p.source, start)
+
+	return errs.Span{
+		Start: p.cursor,
+		End:   p.cursor.Advance(p.source, end),
 	}
+}
 
-	var err error
-	if leftValue == nil {
-		leftValue, err = p.literal()
-		if p.lookahead1 == nil || err != nil {
-			return leftValue, err
-		}
+// endSpan returns the empty span at the end of the source.
+func (p *parser) endSpan() errs.Span {
+	return p.span(len(p.source), len(p.source))
+}
+
+// subSpan returns the span between the byte offsets of the source, which must
+// be within the token, as the positions are scanned from the start of the token.
+func (p *parser) subSpan(token *token, start, end int) errs.Span {
+	begin := token.span.Start.Advance(p.source, start)
+
+	return errs.Span{
+		Start: begin,
+		End:   begin.Advance(p.source, end),
 	}
+}
+
+// advance returns the lookahead and reads the next token.
+func (p *parser) advance() token {
+	token := p.lookahead
+	p.next()
+
+	return token
+}
+
+// isOperation checks if the lookahead is a binary operation.
+func (p *parser) isOperation() bool {
+	operator, ok := operators[p.lookahead.Value]
 
-	if p.lookahead1.Type == arithmeticOperationType {
-		return p.arithmeticOperation(leftValue)
-	} else if p.lookahead1.Type == logicalOperationType {
-		return p.logicalOperation(leftValue)
-	} else if p.lookahead1.Type == comparisonOperationType {
-		return p.comparisonOperation(leftValue)
-	} else if p.lookahead1.Type == contextEndType {
-		return leftValue, nil
+	return ok && operator.tokenType == p.lookahead.Type
+}
+
+// fail records the error and returns an error node for it. Errors starting
+// within or right at the end of the previous error are most likely caused
+// by it and are not recorded.
+func (p *parser) fail(err errs.ErrorAtPositionError) *Node {
+	span := err.Span()
+
+	if span.Start.Offset > p.errorEnd {
+		p.errors = append(p.errors, err)
+		p.errorEnd = span.End.Offset
 	}
 
-	return nil, errs.NewErrorAtPosition(
-		errs.NewErrUnexpectedTokenType(p.lookahead1.Type.String(), "any"),
-		p.tokenizer.GetCursorPosition())
+	return &Node{
+		Kind: ErrorNode,
+		Span: span,
+		Err:  err,
+	}
 }
 
-func (p *parser) contextExpression() (interface{}, error) {
-	_, err := p.eat(contextStartType)
-	if err != nil {
-		return nil, errs.NewErrorAtPosition(err, p.tokenizer.GetCursorPosition())
+// unexpected fails on the lookahead, which is not the expected token type.
+func (p *parser) unexpected(expected string) *Node {
+	if p.lookahead.Type == endType {
+		return p.fail(errs.NewErrorAtSpan(
+			errs.NewErrUnexpectedInputEnd(expected),
+			p.endSpan()))
 	}
 
-	value, err := p.expression(nil)
-	if err != nil {
-		return nil, err
+	if p.lookahead.Type == invalidType {
+		return p.fail(errs.NewErrorAtSpan(
+			errs.NewErrUnexpectedCharacter(p.lookahead.Value),
+			p.lookahead.span))
 	}
 
-	_, err = p.eat(contextEndType)
-	if err != nil {
-		return nil, errs.NewErrorAtPosition(err, p.tokenizer.GetCursorPosition())
+	return p.fail(errs.NewErrorAtSpan(
+		errs.NewErrUnexpectedTokenType(p.lookahead.Type.String(), expected),
+		p.lookahead.span))
+}
+
+// synchronize skips the lookahead and all following tokens up to the next
+// operation, separator or closing parenthesis outside of skipped parentheses.
+func (p *parser) synchronize() {
+	depth := 0
+
+	for p.advance(); p.lookahead.Type != endType; p.advance() {
+		switch {
+		case p.lookahead.Type == contextStartType:
+			depth++
+		case p.lookahead.Type == contextEndType && depth > 0:
+			depth--
+		case depth == 0 && (p.lookahead.Type == contextEndType || p.lookahead.Type == separatorType || p.isOperation()):
+			return
+		}
 	}
+}
+
+// parse the whole expression into a tree, which is partial on errors.
+func (p *parser) parse() *Node {
+	p.next()
+
+	root := p.rest(p.expression(lowestPrecedence))
+	p.checkDepth(root)
 
-	if p.lookahead1 != nil {
-		return p.expression(value)
+	return root
+}
+
+// rest fails on tokens following a complete expression and continues with
+// the operations after them, up to the end or one of the terminators.
+func (p *parser) rest(left *Node, terminators ...tokenType) *Node {
+	for p.lookahead.Type != endType && !slices.Contains(terminators, p.lookahead.Type) {
+		p.unexpected("operation")
+		p.synchronize()
+
+		left = p.operations(left, lowestPrecedence)
 	}
 
-	return value, nil
+	return left
 }
 
-func (p *parser) arithmeticOperation(leftValue interface{}) (interface{}, error) {
-	token, err := p.eat(arithmeticOperationType)
-	if err != nil {
-		return nil, errs.NewErrorAtPosition(err, p.tokenizer.GetCursorPosition())
+// expression parses operations that bind at least as tight as minPrecedence.
+func (p *parser) expression(minPrecedence int) *Node {
+	if p.config.maxDepth > 0 && p.depth >= p.config.maxDepth {
+		return p.tooDeep()
 	}
 
-	if token.Value == "*" || token.Value == "/" || token.Value == "%" {
-		rightValue, err := p.literal()
-		if err != nil {
-			return nil, err
+	p.depth++
+	node := p.operations(p.primary(), minPrecedence)
+	p.depth--
+
+	return node
+}
+
+// operations parses the operations following the left operand
+// that bind at least as tight as minPrecedence.
+func (p *parser) operations(left *Node, minPrecedence int) *Node {
+	for p.isOperation() {
+		operator := operators[p.lookahead.Value]
+		if operator.precedence < minPrecedence {
+			break
 		}
 
-		rightValueConverted := convertFloat(rightValue)
-		switch token.Value {
-		case "*":
-			rightValue = convertFloat(leftValue) * rightValueConverted
-		case "/":
-			if rightValueConverted == 0 {
-				return nil, errs.NewErrorAtPosition(
-					errs.ErrDivisionByZero,
-					p.tokenizer.GetCursorPosition())
-			}
-			rightValue = convertFloat(leftValue) / rightValueConverted
-		case "%":
-			if rightValueConverted == 0 {
-				return nil, errs.NewErrorAtPosition(
-					errs.ErrDivisionByZero,
-					p.tokenizer.GetCursorPosition())
-			}
-			rightValue = int64(math.Round(convertFloat(leftValue))) % int64(math.Round(rightValueConverted))
+		token := p.advance()
+
+		operation := token.Value
+		if token.keyword == "is" && p.lookahead.keyword == "not" {
+			p.advance()
+
+			operation = "!="
 		}
 
-		if p.lookahead1 == nil {
-			return rightValue, nil
+		nextPrecedence := operator.precedence + 1
+		if operator.rightAssociative {
+			nextPrecedence = operator.precedence
 		}
 
-		return p.expression(rightValue)
+		left = &Node{
+			Kind:     BinaryNode,
+			Operator: operation,
+			Left:     left,
+			Right:    p.expression(nextPrecedence),
+			Span:     token.span,
+		}
 	}
 
-	var rightValue interface{}
-	if p.lookahead2 == nil || p.lookahead2.Type != arithmeticOperationType {
-		rightValue, err = p.literal()
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		rightValue, err = p.expression(nil)
-		if err != nil {
-			return nil, err
-		}
+	return left
+}
+
+func (p *parser) primary() *Node {
+	if p.lookahead.Type == endType {
+		return p.unexpected("literal")
 	}
 
-	if token.Value == "+" {
-		leftValue = convertFloat(leftValue) + convertFloat(rightValue)
-	} else if token.Value == "-" {
-		leftValue = convertFloat(leftValue) - convertFloat(rightValue)
+	switch p.lookahead.Type {
+	case contextStartType:
+		return p.contextExpression()
+	case numberType, textType, boolType:
+		return p.literal()
+	case identifierType:
+		return p.identifier()
+	case invalidType:
+		node := p.unexpected("literal")
+		p.advance()
+
+		return node
 	}
 
-	if p.lookahead1 != nil {
-		return p.expression(leftValue)
-	} else {
-		return leftValue, nil
+	if p.lookahead.Type == logicalOperationType && p.lookahead.Value == "!" {
+		return p.unaryOperation()
 	}
+
+	// operations and closing parentheses are left to synchronize on
+	return p.unexpected("literal")
 }
 
-func (p *parser) logicalOperation(leftValue interface{}) (interface{}, error) {
-	token, err := p.eat(logicalOperationType)
-	if err != nil {
-		return nil, errs.NewErrorAtPosition(err, p.tokenizer.GetCursorPosition())
+// identifier parses a variable or a function call.
+func (p *parser) identifier() *Node {
+	token := p.advance()
+	if p.lookahead.Type == contextStartType {
+		return p.call(token)
 	}
 
-	rightValue, err := p.expression(nil)
-	if err != nil {
-		return nil, err
+	if _, ok := p.config.env[token.Value]; p.config.declared && p.config.resolver == nil && !ok {
+		return p.fail(errs.NewErrorAtSpan(
+			errs.NewErrUnknownIdentifier(token.Value, suggest(token.Value, p.config.env.names())...),
+			token.span))
 	}
 
-	switch token.Value {
-	case "&&":
-		return convertBool(leftValue) && convertBool(rightValue), nil
-	case "||":
-		return convertBool(leftValue) || convertBool(rightValue), nil
+	return &Node{
+		Kind: IdentifierNode,
+		Name: token.Value,
+		Span: token.span,
 	}
-
-	return nil, errs.NewErrorAtPosition(
-		errs.NewErrUnexpectedTokenType(token.Type.String(), "logical operation"),
-		p.tokenizer.GetCursorPosition())
 }
 
-func (p *parser) comparisonOperation(leftValue interface{}) (interface{}, error) {
-	token, err := p.eat(comparisonOperationType)
-	if err != nil {
-		return nil, errs.NewErrorAtPosition(err, p.tokenizer.GetCursorPosition())
+// call parses the arguments of the named function. Calls of unknown
+// functions are replaced by an error node after parsing the arguments.
+func (p *parser) call(name token) *Node {
+	node := &Node{
+		Kind: CallNode,
+		Name: name.Value,
+		Span: name.span,
 	}
 
-	rightValue, err := p.expression(nil)
-	if err != nil {
-		return nil, err
+	if _, ok := p.config.functions[name.Value]; !ok {
+		node = p.fail(errs.NewErrorAtSpan(
+			errs.NewErrUnknownFunction(name.Value, suggest(name.Value, p.functionNames())...),
+			name.span))
 	}
 
-	switch token.Value {
-	case "==":
-		return leftValue == rightValue, nil
-	case "!=":
-		return leftValue != rightValue, nil
-	case "<":
-		return convertFloat(leftValue) < convertFloat(rightValue), nil
-	case "<=":
-		return convertFloat(leftValue) <= convertFloat(rightValue), nil
-	case ">":
-		return convertFloat(leftValue) > convertFloat(rightValue), nil
-	case ">=":
-		return convertFloat(leftValue) >= convertFloat(rightValue), nil
+	p.advance()
+
+	for p.lookahead.Type != endType && p.lookahead.Type != contextEndType {
+		node.Args = append(node.Args, p.rest(p.expression(lowestPrecedence), separatorType, contextEndType))
+
+		if p.lookahead.Type != separatorType {
+			break
+		}
+
+		p.advance()
 	}
 
-	return nil, errs.NewErrorAtPosition(
-		errs.NewErrUnexpectedTokenType(token.Type.String(), "comparison operation"),
-		p.tokenizer.GetCursorPosition())
+	if p.lookahead.Type == endType {
+		p.unexpected(contextEndType.String())
+	} else {
+		p.advance()
+	}
+
+	return node
 }
 
-func (p *parser) literal() (interface{}, error) {
-	if p.lookahead1 == nil {
-		return nil, errs.NewErrorAtPosition(
-			errs.NewErrUnexpectedInputEnd("literal"),
-			p.tokenizer.GetCursorPosition())
+// functionNames returns the names of the registered functions sorted.
+func (p *parser) functionNames() []string {
+	names := make([]string, 0, len(p.config.functions))
+	for name := range p.config.functions {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+func (p *parser) unaryOperation() *Node {
+	token := p.advance()
+
+	precedence := unaryPrecedence
+	if token.keyword == "not" {
+		precedence = wordNotPrecedence
 	}
 
-	switch p.lookahead1.Type {
+	return &Node{
+		Kind:     UnaryNode,
+		Operator: token.Value,
+		Left:     p.expression(precedence),
+		Span:     token.span,
+	}
+}
+
+func (p *parser) contextExpression() *Node {
+	p.advance()
+
+	value := p.rest(p.expression(lowestPrecedence), contextEndType)
+	if p.lookahead.Type == endType {
+		p.unexpected(contextEndType.String())
+	} else {
+		p.advance()
+	}
+
+	return value
+}
+
+func (p *parser) literal() *Node {
+	token := p.advance()
+
+	var (
+		value interface{}
+		err   error
+	)
+
+	switch token.Type {
 	case numberType:
-		return p.number()
+		value, err = p.number(&token)
 	case textType:
-		return p.text()
-	case boolType:
-		return p.boolean()
+		value, err = p.text(&token)
+		if text, ok := value.(string); ok && p.config.maxStringLength > 0 && len(text) > p.config.maxStringLength {
+			err = errs.NewErrorAtSpan(errs.ErrStringTooLong, token.span)
+		}
+	default:
+		value = strings.ToLower(token.Value) == "true"
+	}
+
+	var located errs.ErrorAtPositionError
+	if errors.As(err, &located) {
+		return p.fail(located)
 	}
 
-	return nil, errs.NewErrorAtPosition(
-		errs.NewErrUnexpectedTokenType(p.lookahead1.Type.String(), "literal"),
-		p.tokenizer.GetCursorPosition())
+	return &Node{
+		Kind:  LiteralNode,
+		Value: value,
+		Span:  token.span,
+	}
 }
 
-func (p *parser) number() (interface{}, error) {
-	token, err := p.eat(numberType)
-	if err != nil {
-		return nil, errs.NewErrorAtPosition(err, p.tokenizer.GetCursorPosition())
+func (p *parser) number(token *token) (interface{}, error) {
+	switch token.Value {
+	case "Inf":
+		return math.Inf(1), nil
+	case "NaN":
+		return math.NaN(), nil
 	}
 
-	var value float64
-	value, err = strconv.ParseFloat(token.Value, float64Size)
+	number, invalid := scanNumber(token.Value)
+	if invalid != -1 {
+		return nil, errs.NewErrorAtSpan(
+			errs.NewErrMalformedNumber(token.Value),
+			p.subSpan(token, token.span.Start.Offset+invalid, token.span.End.Offset))
+	}
+
+	if !number.float {
+		return p.integer(token, number)
+	}
+
+	if number.base == hexadecimalBase {
+		number.digits = "0x" + number.digits
+	}
+
+	value, err := strconv.ParseFloat(number.digits, float64Size)
 	if err != nil {
-		return nil, errs.NewErrorAtPosition(
-			fmt.Errorf("failed to parse float value: %w", err),
-			p.tokenizer.GetCursorPosition()-len(token.Value))
+		return nil, errs.NewErrorAtSpan(errs.ErrNumberOutOfRange, token.span)
 	}
 
 	return value, nil
 }
 
-func (p *parser) boolean() (interface{}, error) {
-	token, err := p.eat(boolType)
-	if err != nil {
-		return nil, errs.NewErrorAtPosition(err, p.tokenizer.GetCursorPosition())
+// integer returns a float64 unless integers are enabled.
+func (p *parser) integer(token *token, number numberLiteral) (interface{}, error) {
+	value, _ := new(big.Int).SetString(number.digits, number.base)
+
+	if !p.config.integers {
+		float, _ := new(big.Float).SetInt(value).Float64()
+		if math.IsInf(float, 0) {
+			return nil, errs.NewErrorAtSpan(errs.ErrNumberOutOfRange, token.span)
+		}
+
+		return float, nil
+	}
+
+	if value.IsInt64() {
+		return value.Int64(), nil
+	}
+
+	if p.config.overflow == OverflowPromote {
+		return value, nil
 	}
 
-	return strings.ToLower(token.Value) == "true", nil
+	return nil, errs.NewErrorAtSpan(errs.ErrIntegerOverflow, token.span)
 }
 
-func (p *parser) text() (interface{}, error) {
-	token, err := p.eat(textType)
-	if err != nil {
-		return nil, errs.NewErrorAtPosition(err, p.tokenizer.GetCursorPosition())
+func (p *parser) text(token *token) (interface{}, error) {
+	value, sequence, invalid := unquote(token.Value)
+	if invalid != -1 {
+		start := token.span.Start.Offset + invalid
+
+		return nil, errs.NewErrorAtSpan(
+			errs.NewErrInvalidEscape(sequence),
+			p.subSpan(token, start, start+len(sequence)))
 	}
 
-	return token.Value[1 : len(token.Value)-1], nil
+	return value, nil
 }
@@ -2,13 +2,29 @@ package expr
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"math/rand"
+	"strings"
 	"testing"
 
 	"github.com/StevenCyb/goeval/pkg/errs"
 	"github.com/stretchr/testify/assert"
 )
 
+// backends are the evaluations of a program the test tables run against.
+var backends = []struct {
+	name string
+	opts []Option
+}{
+	{name: "Tree"},
+	{name: "Bytecode", opts: []Option{WithBytecode()}},
+}
+
+func errorAt(source string, start, end int, err error) error {
+	return errs.NewErrorAtSpan(err, errs.NewSpan(source, start, end))
+}
+
 func generateRandomString(t *testing.T) string {
 	t.Helper()
 
@@ -52,6 +68,7 @@ func Test_Eval(t *testing.T) {
 		{name: "Longer_Chained_Logical_False", expression: " false  || false && true || false ", result: Result{Value: false}},
 		{name: "Comparison_Number", expression: " 1 > 3 ", result: Result{Value: false}},
 		{name: "Chained_Comparison_Number", expression: " 1+5 > 3 ", result: Result{Value: true}},
+		{name: "Comment", expression: "1 + /* two */ 2 /**/", result: Result{Value: float64(3)}},
 		{name: "Comparison_String_Number", expression: " 3 <= 'hello' ", result: Result{Value: true}},
 		{name: "Comparison_Bool", expression: " true == true ", result: Result{Value: true}},
 		{name: "Added_String_Comparison", expression: ` "a"+"b"==2`, result: Result{Value: true}},
@@ -60,6 +77,158 @@ func Test_Eval(t *testing.T) {
 		{name: "Chained_Simple_Context", expression: " (1+2)*2 ", result: Result{Value: float64(6)}},
 		{name: "Chained_Simple_Context", expression: " ('a'+'b')-'c' ", result: Result{Value: float64(1)}},
 		{name: "Chained_Simple_Context", expression: `("a"!="b")`, result: Result{Value: true}},
+		{name: "Power", expression: "2**3", result: Result{Value: float64(8)}},
+		{name: "Chained_Power", expression: "1+2**3", result: Result{Value: float64(9)}},
+		{name: "Power_Right_Associative", expression: "2**3**2", result: Result{Value: float64(512)}},
+		{name: "Power_Context", expression: "(2**3)**2", result: Result{Value: float64(64)}},
+		{name: "Subtract_Left_Associative", expression: "1-2+3", result: Result{Value: float64(2)}},
+		{name: "Divide_Left_Associative", expression: "8/2/2", result: Result{Value: float64(2)}},
+		{name: "Integer_Division", expression: "7//2", result: Result{Value: float64(3)}},
+		{name: "Integer_Division_Negative", expression: "(0-7)//2", result: Result{Value: float64(-3)}},
+		{name: "Bitwise_And", expression: "6&3", result: Result{Value: float64(2)}},
+		{name: "Bitwise_Or", expression: "6|3", result: Result{Value: float64(7)}},
+		{name: "Bitwise_Xor", expression: "6^3", result: Result{Value: float64(5)}},
+		{name: "Bitwise_And_Not", expression: "6&^3", result: Result{Value: float64(4)}},
+		{name: "Shift_Left", expression: "1<<4", result: Result{Value: float64(16)}},
+		{name: "Shift_Right", expression: "16>>2", result: Result{Value: float64(4)}},
+		{name: "Shift_Precedence", expression: "1+1<<2", result: Result{Value: float64(5)}},
+		{name: "Bitwise_Or_Precedence", expression: "1|2*2", result: Result{Value: float64(5)}},
+		{name: "Bitwise_And_Precedence", expression: "1+6&3", result: Result{Value: float64(3)}},
+		{name: "Bitwise_Before_Comparison", expression: "6&3 == 2", result: Result{Value: true}},
+		{name: "Bitwise_Next_To_Logical", expression: "1&1==1 && 2|1==3", result: Result{Value: true}},
+		{name: "Comparison_Before_Logical", expression: "1 < 2 && 3 > 2", result: Result{Value: true}},
+		{name: "Logical_And_Before_Or", expression: "true || false && false", result: Result{Value: true}},
+		{name: "Number_Fraction_Only", expression: ".5", result: Result{Value: float64(0.5)}},
+		{name: "Number_Exponent", expression: "1e6", result: Result{Value: float64(1e6)}},
+		{name: "Number_Negative_Exponent", expression: "15e-1", result: Result{Value: float64(1.5)}},
+		{name: "Number_Exponent_Next_To_Operation", expression: "1e3-1", result: Result{Value: float64(999)}},
+		{name: "Number_Underscore", expression: "1_000_000", result: Result{Value: float64(1000000)}},
+		{name: "Number_Hexadecimal", expression: "0xFF", result: Result{Value: float64(255)}},
+		{name: "Number_Hexadecimal_Float", expression: "0x1p-2", result: Result{Value: float64(0.25)}},
+		{name: "Number_Hexadecimal_Next_To_Operation", expression: "0x1e+2", result: Result{Value: float64(32)}},
+		{name: "Number_Octal", expression: "0o755", result: Result{Value: float64(493)}},
+		{name: "Number_Binary", expression: "0b1010", result: Result{Value: float64(10)}},
+		{name: "Number_Leading_Zero", expression: "010", result: Result{Value: float64(10)}},
+		{name: "Number_Inf", expression: "Inf", result: Result{Value: math.Inf(1)}},
+		{name: "Number_Inf_Comparison", expression: "Inf > 1e308", result: Result{Value: true}},
+		{name: "Number_NaN_Equal", expression: "NaN == NaN", result: Result{Value: false}},
+		{name: "Number_NaN_Not_Equal", expression: "NaN != NaN", result: Result{Value: true}},
+		{name: "Number_NaN_Less", expression: "NaN <= 1", result: Result{Value: false}},
+		{name: "Number_NaN_Greater", expression: "NaN >= 1", result: Result{Value: false}},
+		{name: "String_Escaped_Quote", expression: `'it\'s'`, result: Result{Value: "it's"}},
+		{name: "String_Escaped_Newline", expression: `"a\nb"`, result: Result{Value: "a\nb"}},
+		{name: "String_Unicode", expression: `'\u00e4' == "ä"`, result: Result{Value: true}},
+		{name: "String_Raw", expression: "`C:\\new\\path`", result: Result{Value: `C:\new\path`}},
+		{name: "String_Raw_Length", expression: "`\\d+`+0 == 3", result: Result{Value: true}},
+		{name: "Boolean_Upper_Case", expression: "TRUE && True", result: Result{Value: true}},
+		{name: "Boolean_Mixed_Case", expression: "FaLsE", result: Result{Value: false}},
+		{name: "Not", expression: "!false", result: Result{Value: true}},
+		{name: "Not_Not", expression: "!!true", result: Result{Value: true}},
+		{name: "Not_Binds_Tight", expression: "!true == false", result: Result{Value: true}},
+		{name: "Not_Context", expression: "!(1 > 2)", result: Result{Value: true}},
+		{name: "Not_Equal_Not_Confused", expression: "1 != 2", result: Result{Value: true}},
+		{name: "Logical_Short_Circuit", expression: "false && 1/0", result: Result{Value: false}},
+	}
+
+	for _, backend := range backends {
+		for _, tc := range tcs {
+			tcRef, backendRef := tc, backend
+
+			t.Run(backendRef.name+"/"+tcRef.name, func(t *testing.T) {
+				t.Parallel()
+
+				defer func() {
+					if r := recover(); r == nil {
+						if tcRef.expectPanic {
+							t.Errorf("expected panic")
+						}
+					} else {
+						t.Errorf("unexpected panic on '%s': %v", tcRef.name, r)
+					}
+				}()
+
+				expression := fmt.Sprintf(tcRef.expression, tcRef.expressionA...)
+				result := EvalWithOptions(expression, backendRef.opts...)
+				assert.Equal(t, tcRef.result, result, expression)
+			})
+		}
+	}
+}
+
+func Test_EvalWithOptions_Integers(t *testing.T) {
+	t.Parallel()
+
+	maxPlusOne, _ := new(big.Int).SetString("9223372036854775808", 10)
+
+	tcs := []struct {
+		name       string
+		expression string
+		overflow   Overflow
+		result     interface{}
+		err        error
+	}{
+		{name: "Number", expression: "2", result: int64(2)},
+		{name: "Float_Number", expression: "2.5", result: float64(2.5)},
+		{name: "Add", expression: "1+2", result: int64(3)},
+		{name: "Power", expression: "2**10", result: int64(1024)},
+		{name: "Division", expression: "3/2", result: float64(1.5)},
+		{name: "Modulo", expression: "7%3", result: int64(1)},
+		{name: "Comparison", expression: "9223372036854775807 == 9223372036854775807", result: true},
+		{name: "Hexadecimal", expression: "0xFF", result: int64(255)},
+		{name: "Underscore", expression: "1_000", result: int64(1000)},
+		{name: "Exponent_Is_Float", expression: "1e3", result: float64(1000)},
+		{name: "Big_Hexadecimal", expression: "0x8000_0000_0000_0000", overflow: OverflowPromote, result: maxPlusOne},
+		{name: "Add_Overflow_Error", expression: "9223372036854775807+1", err: errs.ErrIntegerOverflow},
+		{name: "Literal_Overflow_Error", expression: "9223372036854775808", err: errs.ErrIntegerOverflow},
+		{name: "Add_Overflow_Promote", expression: "9223372036854775807+1", overflow: OverflowPromote, result: maxPlusOne},
+		{name: "Literal_Overflow_Promote", expression: "9223372036854775808", overflow: OverflowPromote, result: maxPlusOne},
+		{name: "Big_Comparison", expression: "9223372036854775808 > 9223372036854775807", overflow: OverflowPromote, result: true},
+		{name: "Shift_Too_Large_Promote", expression: "1 << 40000000000", overflow: OverflowPromote, err: errs.ErrIntegerOverflow},
+		{name: "Power_Too_Large_Promote", expression: "2 ** 40000000000", overflow: OverflowPromote, err: errs.ErrIntegerOverflow},
+	}
+
+	for _, backend := range backends {
+		for _, tc := range tcs {
+			tcRef, backendRef := tc, backend
+
+			t.Run(backendRef.name+"/"+tcRef.name, func(t *testing.T) {
+				t.Parallel()
+
+				result := EvalWithOptions(tcRef.expression, append(backendRef.opts, WithIntegers(tcRef.overflow))...)
+				if tcRef.err != nil {
+					assert.ErrorIs(t, result.Error, tcRef.err)
+
+					return
+				}
+
+				assert.NoError(t, result.Error)
+				assert.Equal(t, tcRef.result, result.Value)
+			})
+		}
+	}
+}
+
+func Test_EvalWithOptions_WordOperators(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name       string
+		expression string
+		result     interface{}
+		start      int
+		end        int
+		err        error
+	}{
+		{name: "And", expression: "true and false", result: false},
+		{name: "Or", expression: "false OR true", result: true},
+		{name: "Not", expression: "not false", result: true},
+		{name: "Is", expression: "'open' is 'open'", result: true},
+		{name: "Is_Not", expression: "'open' IS NOT 'closed'", result: true},
+		{name: "Not_Binds_Loose", expression: "not 1 is 2", result: true},
+		{name: "Combined", expression: "'open' is 'open' and not false", result: true},
+		{name: "Mixed_With_Symbols", expression: "true && false or !false", result: true},
+		{name: "Word_Prefix_Is_Identifier", expression: "true and notice", start: 9, end: 15, err: errs.NewErrUnknownIdentifier("notice")},
+		{name: "Missing_Operand", expression: "true and", start: 8, end: 8, err: errs.NewErrUnexpectedInputEnd("literal")},
 	}
 
 	for _, tc := range tcs {
@@ -68,22 +237,202 @@ func Test_Eval(t *testing.T) {
 		t.Run(tcRef.name, func(t *testing.T) {
 			t.Parallel()
 
-			defer func() {
-				if r := recover(); r == nil {
-					if tcRef.expectPanic {
-						t.Errorf("expected panic")
-					}
-				} else {
-					t.Errorf("unexpected panic on '%s': %v", tcRef.name, r)
-				}
-			}()
+			result := EvalWithOptions(tcRef.expression, WithWordOperators())
+			if tcRef.err != nil {
+				assert.Equal(t, errorAt(tcRef.expression, tcRef.start, tcRef.end, tcRef.err), result.Error)
+
+				return
+			}
+
+			assert.NoError(t, result.Error)
+			assert.Equal(t, tcRef.result, result.Value)
+		})
+	}
+}
+
+func Test_Eval_Errors(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name       string
+		expression string
+		start      int
+		end        int
+		err        error
+	}{
+		{name: "Division_By_Zero", expression: "1/0", start: 1, end: 2, err: errs.ErrDivisionByZero},
+		{name: "Integer_Division_By_Zero", expression: "1 // 0", start: 2, end: 4, err: errs.ErrDivisionByZero},
+		{name: "Bitwise_Fraction", expression: "1.5 & 1", start: 4, end: 5, err: errs.ErrNotInteger},
+		{name: "Negative_Shift", expression: "1 << (0-1)", start: 2, end: 4, err: errs.ErrNegativeShift},
+		{name: "Malformed_Number_Underscore", expression: "1 + 1__0", start: 6, end: 8, err: errs.NewErrMalformedNumber("1__0")},
+		{name: "Malformed_Number_Digit", expression: "0b102", start: 4, end: 5, err: errs.NewErrMalformedNumber("0b102")},
+		{name: "Malformed_Number_Exponent", expression: "2 * 1e", start: 5, end: 6, err: errs.NewErrMalformedNumber("1e")},
+		{name: "Number_Out_Of_Range", expression: "1 + 1e400", start: 4, end: 9, err: errs.ErrNumberOutOfRange},
+		{name: "Integer_Out_Of_Range", expression: "1 + 1" + strings.Repeat("0", 400), start: 4, end: 405, err: errs.ErrNumberOutOfRange},
+		{name: "Hexadecimal_Out_Of_Range", expression: "0x1" + strings.Repeat("0", 256), start: 0, end: 259, err: errs.ErrNumberOutOfRange},
+		{name: "Malformed_Number_Suffix", expression: "2true", start: 1, end: 5, err: errs.NewErrMalformedNumber("2true")},
+		{name: "Invalid_Escape", expression: `'ok' == 'a\qb'`, start: 10, end: 12, err: errs.NewErrInvalidEscape(`\q`)},
+		{name: "Invalid_Multibyte_Escape", expression: `'\é' == 'a'`, start: 1, end: 4, err: errs.NewErrInvalidEscape(`\é`)},
+		{name: "Invalid_Unicode_Escape", expression: `"\u12"`, start: 1, end: 5, err: errs.NewErrInvalidEscape(`\u12`)},
+		{name: "Keyword_Prefix_Is_Identifier", expression: "trueValue", start: 0, end: 9, err: errs.NewErrUnknownIdentifier("trueValue")},
+		{name: "Word_Operator_Disabled", expression: "true and false", start: 5, end: 8, err: errs.NewErrUnexpectedTokenType("IDENTIFIER", "operation")},
+		{name: "Unexpected_Character", expression: "1 # 2", start: 2, end: 3, err: errs.NewErrUnexpectedCharacter("#")},
+		{name: "Unterminated_Comment", expression: "1 + /*", start: 4, end: 6, err: errs.NewErrUnexpectedCharacter("/*")},
+		{name: "Multi_Line", expression: "1 +\n  'ä' / 0", start: 11, end: 12, err: errs.ErrDivisionByZero},
+		{name: "Leading_Whitespace", expression: "\n\n 1 +", start: 6, end: 6, err: errs.NewErrUnexpectedInputEnd("literal")},
+		{name: "Missing_Operand", expression: "1 +", start: 3, end: 3, err: errs.NewErrUnexpectedInputEnd("literal")},
+		{name: "Missing_Operation", expression: "1 2", start: 2, end: 3, err: errs.NewErrUnexpectedTokenType("NUMBER", "operation")},
+		{name: "Unclosed_Context", expression: "(1", start: 2, end: 2, err: errs.NewErrUnexpectedInputEnd("CONTEXT_END")},
+		{name: "Operation_Instead_Of_Literal", expression: "1 + &&", start: 4, end: 6, err: errs.NewErrUnexpectedTokenType("LOGICAL_OPERATION", "literal")},
+	}
+
+	for _, backend := range backends {
+		for _, tc := range tcs {
+			tcRef, backendRef := tc, backend
+
+			t.Run(backendRef.name+"/"+tcRef.name, func(t *testing.T) {
+				t.Parallel()
+
+				result := EvalWithOptions(tcRef.expression, backendRef.opts...)
+				assert.Equal(t, errorAt(tcRef.expression, tcRef.start, tcRef.end, tcRef.err), result.Error)
+			})
+		}
+	}
+}
+
+func Test_Parse_Recovery(t *testing.T) {
+	t.Parallel()
+
+	type located struct {
+		start int
+		end   int
+		err   error
+	}
+
+	tcs := []struct {
+		name       string
+		expression string
+		errors     []located
+	}{
+		{name: "Operation_Instead_Of_Literal", expression: "1 + * 2", errors: []located{
+			{start: 4, end: 5, err: errs.NewErrUnexpectedTokenType("ARITHMETIC_OPERATION", "literal")},
+		}},
+		{name: "Each_Context", expression: "(1 + ) * (2 ++ 3", errors: []located{
+			{start: 5, end: 6, err: errs.NewErrUnexpectedTokenType("CONTEXT_END", "literal")},
+			{start: 13, end: 14, err: errs.NewErrUnexpectedTokenType("ARITHMETIC_OPERATION", "literal")},
+			{start: 16, end: 16, err: errs.NewErrUnexpectedInputEnd("CONTEXT_END")},
+		}},
+		{name: "Literals_And_Calls", expression: "1 + 1__0 + 'a\\q' + foo(1, 2 +)", errors: []located{
+			{start: 6, end: 8, err: errs.NewErrMalformedNumber("1__0")},
+			{start: 13, end: 15, err: errs.NewErrInvalidEscape(`\q`)},
+			{start: 19, end: 22, err: errs.NewErrUnknownFunction("foo")},
+			{start: 29, end: 30, err: errs.NewErrUnexpectedTokenType("CONTEXT_END", "literal")},
+		}},
+		{name: "Synchronize_On_Operation", expression: "1 # 2 + 3 4", errors: []located{
+			{start: 2, end: 3, err: errs.NewErrUnexpectedCharacter("#")},
+			{start: 10, end: 11, err: errs.NewErrUnexpectedTokenType("NUMBER", "operation")},
+		}},
+		{name: "Skip_Nested_Context", expression: "1 2 (3 4) + 5 6", errors: []located{
+			{start: 2, end: 3, err: errs.NewErrUnexpectedTokenType("NUMBER", "operation")},
+			{start: 14, end: 15, err: errs.NewErrUnexpectedTokenType("NUMBER", "operation")},
+		}},
+		{name: "Suppress_Follow_Up", expression: "1 + &&", errors: []located{
+			{start: 4, end: 6, err: errs.NewErrUnexpectedTokenType("LOGICAL_OPERATION", "literal")},
+		}},
+		{name: "Unopened_Context", expression: "1) + (2", errors: []located{
+			{start: 1, end: 2, err: errs.NewErrUnexpectedTokenType("CONTEXT_END", "operation")},
+			{start: 7, end: 7, err: errs.NewErrUnexpectedInputEnd("CONTEXT_END")},
+		}},
+	}
 
-			result := Eval(tcRef.expression, tcRef.expressionA...)
-			assert.Equal(t, tcRef.result, result, fmt.Sprintf(tc.expression, tc.expressionA...))
+	for _, tc := range tcs {
+		tcRef := tc
+
+		t.Run(tcRef.name, func(t *testing.T) {
+			t.Parallel()
+
+			expected := errs.List{}
+			for _, located := range tcRef.errors {
+				expected = append(expected, errorAt(tcRef.expression, located.start, located.end, located.err))
+			}
+
+			_, err := Parse(tcRef.expression)
+			assert.Equal(t, expected.Err(), err)
+			assert.Equal(t, expected.Err(), Eval(tcRef.expression).Error)
 		})
 	}
 }
 
+func Test_Parse_Partial_Tree(t *testing.T) {
+	t.Parallel()
+
+	expression := "(1 + ) * 2"
+	root, err := Parse(expression)
+
+	assert.Error(t, err)
+	assert.Equal(t, BinaryNode, root.Kind)
+	assert.Equal(t, "*", root.Operator)
+	assert.Equal(t, 2.0, root.Right.Value)
+	assert.Equal(t, BinaryNode, root.Left.Kind)
+	assert.Equal(t, 1.0, root.Left.Left.Value)
+	assert.Equal(t, ErrorNode, root.Left.Right.Kind)
+	assert.Equal(t, err, root.Left.Right.Err)
+
+	root, err = Parse("1 + 2 * 3")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "+", root.Operator)
+	assert.Equal(t, "*", root.Right.Operator)
+
+	_, err = Parse(" ")
+	assert.ErrorIs(t, err, errs.ErrEmptyExpression)
+}
+
+func Test_Eval_Error_Kind(t *testing.T) {
+	t.Parallel()
+
+	result := Eval("1 + (2 / 0)")
+
+	var located errs.Error
+
+	assert.ErrorIs(t, result.Error, errs.ErrDivisionByZero)
+	assert.ErrorAs(t, result.Error, &located)
+	assert.Equal(t, errs.CodeDivisionByZero, located.Code())
+
+	var unexpected errs.UnexpectedTokenTypeError
+
+	assert.ErrorAs(t, Eval("(1 + )").Error, &unexpected)
+	assert.Equal(t, "CONTEXT_END", unexpected.Actual())
+	assert.Equal(t, errs.CodeUnexpectedToken, errs.CodeOf(Eval("(1 + )").Error))
+}
+
+func Test_Eval_Error_Span(t *testing.T) {
+	t.Parallel()
+
+	result := Eval("true &&\n  'ä' // 0")
+
+	var err errs.ErrorAtPositionError
+
+	assert.ErrorAs(t, result.Error, &err)
+	assert.Equal(t, errs.Span{
+		Start: errs.Position{Offset: 15, Line: 2, Column: 7},
+		End:   errs.Position{Offset: 17, Line: 2, Column: 9},
+	}, err.Span())
+}
+
+func Test_Eval_Error_Format(t *testing.T) {
+	t.Parallel()
+
+	expression := "(1 + )"
+	assert.Equal(t, ""+
+		"error: unexpected ')', expected literal\n"+
+		" --> line 1, column 6\n"+
+		"  |\n"+
+		"1 | (1 + )\n"+
+		"  |      ^",
+		errs.Format(Eval(expression).Error, expression))
+}
+
 func Test_Eval_Panic_Penetration(t *testing.T) {
 	t.Parallel()
 
@@ -121,7 +470,7 @@ func Test_Eval_Semi_Read_Penetration(t *testing.T) {
 		},
 	}
 	operationGenerator := func() string {
-		operations := []string{"+", "-", "*", "/", "%", "&&", "||", "==", "!=", "<", "<=", ">", ">="}
+		operations := []string{"+", "-", "*", "/", "//", "%", "**", "&", "|", "^", "&^", "<<", ">>", "&&", "||", "==", "!=", "<", "<=", ">", ">="}
 		return operations[rand.Intn(len(operations))]
 	}
 	expressionGenerator := func() string {
@@ -158,3 +507,24 @@ func Test_Eval_Semi_Read_Penetration(t *testing.T) {
 		Eval(input + ";")
 	}
 }
+
+func BenchmarkCompile(b *testing.B) {
+	expression := "(price * 1_000 + 0x1F) / 3 >= limit && !(country == 'DE' || name != \"x\\ty\") || 2 ** 10 > 1e3"
+	opts := []Option{WithEnv(Env{"price": 1, "limit": 2, "country": "DE", "name": "x"})}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := Compile(expression, opts...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEval(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		Eval("(1 + 2) * 3 > 4 && 'a' != 'b'")
+	}
+}
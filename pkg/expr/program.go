@@ -0,0 +1,124 @@
+package expr
+
+import (
+	"context"
+	"strings"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+)
+
+// Program is a compiled expression to evaluate many times.
+// It is immutable and safe for concurrent use by multiple goroutines,
+// every evaluation has its own state. Functions and the resolver
+// are called concurrently then and must be safe for it as well.
+type Program struct {
+	source string
+	config *config
+	root   *Node
+	cost   int
+	// code is the compiled tree if WithBytecode is set.
+	code *bytecode
+}
+
+// Compile parses the expression once, so it can be evaluated
+// with different environments. Unless the variables are declared
+// by WithEnv, unknown identifiers are reported on evaluation.
+func Compile(expression string, opts ...Option) (*Program, error) {
+	return compile(context.Background(), expression, newConfig(opts...))
+}
+
+// compile folds constants with the context, if optimized.
+func compile(ctx context.Context, expression string, cfg *config) (*Program, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, errs.ErrEmptyExpression
+	}
+
+	if cfg.maxSourceLength > 0 && len(expression) > cfg.maxSourceLength {
+		return nil, errs.ErrSourceTooLong
+	}
+
+	cfg = cfg.clone()
+	p := newParser(expression, cfg)
+
+	root := p.parse()
+	if err := p.errors.Err(); err != nil {
+		return nil, err
+	}
+
+	if cfg.optimize {
+		root = optimize(ctx, root, cfg)
+	}
+
+	cost := estimateCost(root)
+	if cfg.maxCost > 0 && cost > cfg.maxCost {
+		return nil, errs.NewErrorAtSpan(errs.ErrMaxCost, errs.NewSpan(expression, 0, len(expression)))
+	}
+
+	program := &Program{
+		source: expression,
+		config: cfg,
+		root:   root,
+		cost:   cost,
+	}
+
+	if cfg.bytecode {
+		program.code = compileBytecode(root, cfg.integers)
+	}
+
+	return program, nil
+}
+
+// Source returns the expression the program is compiled from.
+func (p *Program) Source() string {
+	return p.source
+}
+
+// String returns the compiled tree, see Node.String.
+func (p *Program) String() string {
+	return p.root.String()
+}
+
+// Cost returns the estimated cost of an evaluation, which is the number
+// of operations and variables plus ten for every function call.
+func (p *Program) Cost() int {
+	return p.cost
+}
+
+// Eval evaluates the program with the variables of the environment,
+// if env is nil the environment of WithEnv is used.
+func (p *Program) Eval(env Env) Result {
+	return p.EvalContext(context.Background(), env)
+}
+
+// EvalContext is like Eval, but stops with the error of the context located
+// at the current node once it is done. The context is passed to functions
+// and resolvers.
+func (p *Program) EvalContext(ctx context.Context, env Env) Result {
+	return p.eval(&evaluation{ctx: ctx, config: p.config}, env)
+}
+
+// eval evaluates with the environment, the evaluation is reset so it can be reused.
+func (p *Program) eval(e *evaluation, env Env) Result {
+	value, err := p.value(e, env)
+	if err != nil {
+		return Result{Error: err}
+	}
+
+	return Result{Value: value.boxed()}
+}
+
+// value is like eval, but returns the value unboxed.
+func (p *Program) value(e *evaluation, env Env) (tagged, error) {
+	if env == nil {
+		env = p.config.env
+	}
+
+	e.env = env
+	e.steps = 0
+
+	if p.code != nil {
+		return e.run(p.code)
+	}
+
+	return e.evaluate(p.root)
+}
@@ -0,0 +1,364 @@
+package expr
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func length(_ context.Context, args ...interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, errors.New("length expects one argument")
+	}
+
+	text, _ := args[0].(string)
+
+	return len(text), nil
+}
+
+func concat(_ context.Context, args ...interface{}) (interface{}, error) {
+	var builder strings.Builder
+
+	for _, arg := range args {
+		text, _ := arg.(string)
+		builder.WriteString(text)
+	}
+
+	return builder.String(), nil
+}
+
+func Test_Program_Eval(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name       string
+		expression string
+		env        Env
+		opts       []Option
+		result     interface{}
+	}{
+		{name: "Variables", expression: "price * qty > 100", env: Env{"price": 20.5, "qty": 5}, result: true},
+		{name: "Text_Variable", expression: "country == 'DE' && !blocked", env: Env{"country": "DE", "blocked": false}, result: true},
+		{name: "Go_Number_Types", expression: "a + b + c + d", env: Env{"a": int8(1), "b": uint(2), "c": float32(0.5), "d": int64(3)}, result: 6.5},
+		{name: "Integer_Variables", expression: "a * b", env: Env{"a": 3, "b": uint16(4)}, opts: []Option{WithIntegers(OverflowError)}, result: int64(12)},
+		{name: "Function", expression: "length('abc') == 3", result: true},
+		{name: "Function_Without_Arguments", expression: "concat() == ''", result: true},
+		{name: "Function_Arguments", expression: "concat(first, ' ', 'B')", env: Env{"first": "A"}, result: "A B"},
+		{name: "Nested_Functions", expression: "length(concat('ab', 'cd')) * 2", result: 8.0},
+		{name: "Trailing_Separator", expression: "length(\n\t'abc',\n)", result: 3.0},
+	}
+
+	for _, backend := range backends {
+		for _, tc := range tcs {
+			tcRef, backendRef := tc, backend
+
+			t.Run(backendRef.name+"/"+tcRef.name, func(t *testing.T) {
+				t.Parallel()
+
+				opts := append([]Option{WithFunction("length", length), WithFunction("concat", concat)}, backendRef.opts...)
+				program, err := Compile(tcRef.expression, append(opts, tcRef.opts...)...)
+				require.NoError(t, err)
+
+				result := program.Eval(tcRef.env)
+				assert.NoError(t, result.Error)
+				assert.Equal(t, tcRef.result, result.Value)
+				assert.Equal(t, tcRef.expression, program.Source())
+			})
+		}
+	}
+}
+
+func Test_Program_Eval_Env_Default(t *testing.T) {
+	t.Parallel()
+
+	program, err := Compile("a + b", WithEnv(Env{"a": 1, "b": 2}))
+	require.NoError(t, err)
+
+	assert.Equal(t, 3.0, program.Eval(nil).Value)
+	assert.Equal(t, 5.0, program.Eval(Env{"a": 2, "b": 3}).Value)
+	assert.Equal(t, 3.0, EvalWithOptions("a + b", WithEnv(Env{"a": 1, "b": 2})).Value)
+}
+
+func Test_Program_Errors(t *testing.T) {
+	t.Parallel()
+
+	env := Env{"country": "DE", "count": 2, "city": "Berlin"}
+	functions := []Option{WithFunction("length", length), WithFunction("concat", concat)}
+
+	t.Run("Unknown_Identifier_Declared", func(t *testing.T) {
+		t.Parallel()
+
+		expression := "countr == 'DE'"
+		_, err := Compile(expression, append(functions, WithEnv(env))...)
+		assert.Equal(t, errorAt(expression, 0, 6, errs.NewErrUnknownIdentifier("countr", "count", "country")), err)
+	})
+
+	t.Run("Unknown_Identifier_On_Eval", func(t *testing.T) {
+		t.Parallel()
+
+		expression := "1 + (citi == 'Berlin')"
+		program, err := Compile(expression)
+		require.NoError(t, err)
+		assert.Equal(t, errorAt(expression, 5, 9, errs.NewErrUnknownIdentifier("citi", "city")), program.Eval(env).Error)
+	})
+
+	t.Run("Unknown_Function", func(t *testing.T) {
+		t.Parallel()
+
+		expression := "lenght(country) > 1"
+		_, err := Compile(expression, functions...)
+		assert.Equal(t, errorAt(expression, 0, 6, errs.NewErrUnknownFunction("lenght", "length")), err)
+
+		var unknown errs.UnknownFunctionError
+
+		require.ErrorAs(t, err, &unknown)
+		assert.Equal(t, []string{"length"}, unknown.Suggestions())
+	})
+
+	t.Run("Function_Error", func(t *testing.T) {
+		t.Parallel()
+
+		expression := "1 + length(1, 2)"
+		program, err := Compile(expression, functions...)
+		require.NoError(t, err)
+
+		result := program.Eval(nil)
+		assert.EqualError(t, result.Error, "length expects one argument, at line 1, column 5")
+		assert.Equal(t, 4, result.Error.(errs.Error).Span().Start.Offset)
+	})
+
+	t.Run("No_Variables_In_Eval", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, errorAt("value > 1", 0, 5, errs.NewErrUnknownIdentifier("value")), Eval("value > 1").Error)
+	})
+}
+
+func Test_Parse_Call(t *testing.T) {
+	t.Parallel()
+
+	root, err := Parse("concat(a, 'b')", WithFunction("concat", concat))
+	require.NoError(t, err)
+
+	assert.Equal(t, CallNode, root.Kind)
+	assert.Equal(t, "concat", root.Name)
+	assert.Len(t, root.Args, 2)
+	assert.Equal(t, IdentifierNode, root.Args[0].Kind)
+	assert.Equal(t, "a", root.Args[0].Name)
+	assert.Equal(t, "b", root.Args[1].Value)
+}
+
+func Test_Program_Resolver(t *testing.T) {
+	t.Parallel()
+
+	resolver := func(_ context.Context, name string) (interface{}, bool) {
+		if name == "lazy" {
+			return 40, true
+		}
+
+		return nil, false
+	}
+
+	expression := "lazy + known + 1 == unknown"
+	program, err := Compile(expression, WithEnv(Env{"known": 1}), WithResolver(resolver))
+	require.NoError(t, err)
+
+	assert.Equal(t, errorAt(expression, 20, 27, errs.NewErrUnknownIdentifier("unknown", "known")), program.Eval(nil).Error)
+	assert.Equal(t, true, program.Eval(Env{"known": 1, "unknown": 42}).Value)
+}
+
+func Test_Program_Panic_Recovery(t *testing.T) {
+	t.Parallel()
+
+	explode := func(_ context.Context, args ...interface{}) (interface{}, error) {
+		return args[1], nil
+	}
+	resolver := func(_ context.Context, name string) (interface{}, bool) {
+		panic(errs.ErrDivisionByZero)
+	}
+
+	t.Run("Function", func(t *testing.T) {
+		t.Parallel()
+
+		expression := "1 + explode(1)"
+		program, err := Compile(expression, WithFunction("explode", explode))
+		require.NoError(t, err)
+
+		result := program.Eval(nil)
+
+		var panicErr errs.PanicError
+
+		require.ErrorAs(t, result.Error, &panicErr)
+		assert.Equal(t, errs.CodePanic, errs.CodeOf(result.Error))
+		assert.Equal(t, 4, result.Error.(errs.Error).Span().Start.Offset)
+		assert.Contains(t, string(panicErr.Stack()), "Test_Program_Panic_Recovery")
+		assert.Contains(t, result.Error.Error(), "index out of range")
+	})
+
+	t.Run("Resolver", func(t *testing.T) {
+		t.Parallel()
+
+		expression := "(lazy)"
+		program, err := Compile(expression, WithResolver(resolver))
+		require.NoError(t, err)
+
+		result := program.Eval(nil)
+		assert.ErrorIs(t, result.Error, errs.ErrDivisionByZero)
+		assert.Equal(t, errs.CodePanic, errs.CodeOf(result.Error))
+		assert.Equal(t, 1, result.Error.(errs.Error).Span().Start.Offset)
+	})
+
+	t.Run("Without_Recovery", func(t *testing.T) {
+		t.Parallel()
+
+		program, err := Compile("explode(1)", WithFunction("explode", explode), WithoutPanicRecovery())
+		require.NoError(t, err)
+		assert.Panics(t, func() { program.Eval(nil) })
+	})
+}
+
+type contextKey struct{}
+
+func Test_Program_EvalContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Passed_To_Functions_And_Resolvers", func(t *testing.T) {
+		t.Parallel()
+
+		tenant := func(ctx context.Context, _ ...interface{}) (interface{}, error) {
+			return ctx.Value(contextKey{}), nil
+		}
+		resolver := func(ctx context.Context, _ string) (interface{}, bool) {
+			return ctx.Value(contextKey{}), true
+		}
+
+		ctx := context.WithValue(context.Background(), contextKey{}, "acme")
+		result := EvalContext(ctx, "tenant() == name", WithFunction("tenant", tenant), WithResolver(resolver))
+		assert.NoError(t, result.Error)
+		assert.Equal(t, true, result.Value)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		expression := "1 + 2"
+		result := EvalContext(ctx, expression)
+		assert.ErrorIs(t, result.Error, context.Canceled)
+		assert.Equal(t, errorAt(expression, 2, 3, context.Canceled), result.Error)
+		assert.Equal(t, errs.CodeCanceled, errs.CodeOf(result.Error))
+	})
+
+	t.Run("Canceled_During_Evaluation", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stop := func(context.Context, ...interface{}) (interface{}, error) {
+			cancel()
+
+			return true, nil
+		}
+
+		expression := "stop() && stop()"
+		program, err := Compile(expression, WithFunction("stop", stop))
+		require.NoError(t, err)
+		assert.Equal(t, errorAt(expression, 10, 14, context.Canceled), program.EvalContext(ctx, nil).Error)
+	})
+
+	t.Run("Deadline_Exceeded", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		wait := func(ctx context.Context, _ ...interface{}) (interface{}, error) {
+			<-ctx.Done()
+
+			return nil, ctx.Err()
+		}
+
+		result := EvalContext(ctx, "1 + wait()", WithFunction("wait", wait))
+		assert.ErrorIs(t, result.Error, context.DeadlineExceeded)
+		assert.Equal(t, errs.CodeDeadlineExceeded, errs.CodeOf(result.Error))
+	})
+}
+
+func Test_Program_Env_Copied(t *testing.T) {
+	t.Parallel()
+
+	env := Env{"a": 1}
+	program, err := Compile("a + 1", WithEnv(env))
+	require.NoError(t, err)
+
+	env["a"] = 2
+	assert.Equal(t, 2.0, program.Eval(nil).Value)
+}
+
+// Test_Program_Concurrent hammers one program from many goroutines,
+// run it with -race to detect shared state.
+func Test_Program_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	resolver := func(_ context.Context, name string) (interface{}, bool) {
+		return len(name), name != "unknown"
+	}
+	expression := "length(concat(name, suffix)) * factor + lazy > limit && !(name == 'skip')"
+
+	for _, backend := range backends {
+		backendRef := backend
+
+		t.Run(backendRef.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := append([]Option{
+				WithFunction("length", length), WithFunction("concat", concat),
+				WithResolver(resolver), WithOptimize(),
+			}, backendRef.opts...)
+			program, err := Compile(expression, opts...)
+			require.NoError(t, err)
+
+			var wg sync.WaitGroup
+
+			for i := 0; i < 300; i++ {
+				wg.Add(1)
+
+				go func(i int) {
+					defer wg.Done()
+
+					name := strings.Repeat("x", i%7)
+					if i%5 == 0 {
+						name = "skip"
+					}
+
+					env := Env{"name": name, "suffix": "ab", "factor": i % 3, "limit": 10}
+					expected := (len(name)+2)*(i%3)+4 > 10 && name != "skip"
+
+					for j := 0; j < 20; j++ {
+						result := program.Eval(env)
+						if !assert.NoError(t, result.Error) || !assert.Equal(t, expected, result.Value) {
+							return
+						}
+					}
+
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+					assert.ErrorIs(t, program.EvalContext(ctx, env).Error, context.Canceled)
+
+					// the missing limit is resolved as 5
+					assert.Equal(t, true, program.Eval(Env{"name": "a", "suffix": "b", "factor": 1}).Value)
+				}(i)
+			}
+
+			wg.Wait()
+		})
+	}
+}
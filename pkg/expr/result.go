@@ -1,12 +1,16 @@
 package expr
 
-import "errors"
+import (
+	"errors"
+	"math/big"
+)
 
 var (
 	ErrNotString = errors.New("value is not a string")
 	ErrNotInt    = errors.New("value is not an int")
 	ErrNotFloat  = errors.New("value is not a float64")
 	ErrNotBool   = errors.New("value is not a bool")
+	ErrNotBigInt = errors.New("value is not a big int")
 )
 
 type Type string
@@ -15,6 +19,7 @@ const (
 	TypeError   Type = "error"
 	TypeString  Type = "string"
 	TypeInt     Type = "int"
+	TypeBigInt  Type = "bigint"
 	TypeFloat   Type = "float"
 	TypeBool    Type = "bool"
 	TypeUnknown Type = "unknown"
@@ -37,6 +42,8 @@ func (r Result) Type() Type {
 		return TypeString
 	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
 		return TypeInt
+	case *big.Int:
+		return TypeBigInt
 	case float32, float64:
 		return TypeFloat
 	case bool:
@@ -76,12 +83,14 @@ func (r Result) Int() (int, error) {
 		return 0, r.Error
 	}
 
-	value, ok := r.Value.(int)
-	if !ok {
-		return 0, ErrNotInt
+	switch value := r.Value.(type) {
+	case int:
+		return value, nil
+	case int64:
+		return int(value), nil
 	}
 
-	return value, nil
+	return 0, ErrNotInt
 }
 
 // MustInt returns the result as int or panics if not an int or eval failed.
@@ -140,4 +149,32 @@ func (r Result) MustBool() bool {
 	}
 
 	return value
-}
\ No newline at end of file
+}
+
+// BigInt returns the result as *big.Int or error if not an integer.
+func (r Result) BigInt() (*big.Int, error) {
+	if r.Error != nil {
+		return nil, r.Error
+	}
+
+	switch value := r.Value.(type) {
+	case *big.Int:
+		return new(big.Int).Set(value), nil
+	case int64:
+		return big.NewInt(value), nil
+	case int:
+		return big.NewInt(int64(value)), nil
+	}
+
+	return nil, ErrNotBigInt
+}
+
+// MustBigInt returns the result as *big.Int or panics if not an integer or eval failed.
+func (r Result) MustBigInt() *big.Int {
+	value, err := r.BigInt()
+	if err != nil {
+		panic(err)
+	}
+
+	return value
+}
@@ -2,6 +2,7 @@ package expr
 
 import (
 	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,6 +28,11 @@ func Test_Result_Type(t *testing.T) {
 		assert.Equal(t, Result{Value: 1}.Type(), TypeInt)
 	})
 
+	t.Run("BigInt", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, Result{Value: big.NewInt(1)}.Type(), TypeBigInt)
+	})
+
 	t.Run("Float", func(t *testing.T) {
 		t.Parallel()
 		assert.Equal(t, Result{Value: 1.1}.Type(), TypeFloat)
@@ -91,6 +97,13 @@ func Test_Result_As_Int(t *testing.T) {
 		assert.Equal(t, expect, actual)
 	})
 
+	t.Run("Ok_Int64", func(t *testing.T) {
+		t.Parallel()
+		actual, err := Result{Value: int64(1)}.Int()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, actual)
+	})
+
 	t.Run("Not_Of_Type", func(t *testing.T) {
 		t.Parallel()
 		_, err := Result{Value: "string"}.Int()
@@ -117,6 +130,50 @@ func Test_Result_As_Int(t *testing.T) {
 	})
 }
 
+func Test_Result_As_BigInt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Ok", func(t *testing.T) {
+		t.Parallel()
+		expect := big.NewInt(1)
+		actual, err := Result{Value: expect}.BigInt()
+		assert.NoError(t, err)
+		assert.Equal(t, expect, actual)
+	})
+
+	t.Run("Ok_Int64", func(t *testing.T) {
+		t.Parallel()
+		actual, err := Result{Value: int64(1)}.BigInt()
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(1), actual)
+	})
+
+	t.Run("Not_Of_Type", func(t *testing.T) {
+		t.Parallel()
+		_, err := Result{Value: "string"}.BigInt()
+		assert.ErrorIs(t, ErrNotBigInt, err)
+	})
+
+	t.Run("Eval_Error", func(t *testing.T) {
+		t.Parallel()
+		_, err := Result{Error: ErrMockError}.BigInt()
+		assert.ErrorIs(t, ErrMockError, err)
+	})
+
+	t.Run("Must_Ok", func(t *testing.T) {
+		t.Parallel()
+		expect := big.NewInt(1)
+		assert.Equal(t, expect, Result{Value: expect}.MustBigInt())
+	})
+
+	t.Run("Must_Error", func(t *testing.T) {
+		t.Parallel()
+		assert.PanicsWithError(t, ErrNotBigInt.Error(), func() {
+			Result{Value: "string"}.MustBigInt()
+		})
+	})
+}
+
 func Test_Result_As_Float(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,76 @@
+package expr
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	maxSuggestions = 3
+	// suggestionRatio of the name length is the maximum distance of a suggestion.
+	suggestionRatio = 3
+)
+
+// suggest returns up to three candidates closest to the name,
+// ordered by distance and name.
+func suggest(name string, candidates []string) []string {
+	maxDistance := max(1, len(name)/suggestionRatio)
+	distances := map[string]int{}
+
+	var suggestions []string
+
+	for _, candidate := range candidates {
+		distance := editDistance(strings.ToLower(name), strings.ToLower(candidate))
+		if distance <= maxDistance {
+			distances[candidate] = distance
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if distances[suggestions[i]] != distances[suggestions[j]] {
+			return distances[suggestions[i]] < distances[suggestions[j]]
+		}
+
+		return suggestions[i] < suggestions[j]
+	})
+
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+
+	return suggestions
+}
+
+// editDistance is the optimal string alignment distance, the Levenshtein
+// distance where swapping two adjacent characters is a single edit.
+func editDistance(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	rows := make([][]int, len(source)+1)
+
+	for i := range rows {
+		rows[i] = make([]int, len(target)+1)
+		rows[i][0] = i
+	}
+
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && source[i-1] == target[j-2] && source[i-2] == target[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return rows[len(source)][len(target)]
+}
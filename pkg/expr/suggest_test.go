@@ -0,0 +1,46 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EditDistance(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		a        string
+		b        string
+		distance int
+	}{
+		{a: "", b: "", distance: 0},
+		{a: "abc", b: "", distance: 3},
+		{a: "countr", b: "country", distance: 1},
+		{a: "lenght", b: "length", distance: 1},
+		{a: "kitten", b: "sitting", distance: 3},
+		{a: "größe", b: "grösse", distance: 2},
+	}
+
+	for _, tc := range tcs {
+		tcRef := tc
+
+		t.Run(tcRef.a+"_"+tcRef.b, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tcRef.distance, editDistance(tcRef.a, tcRef.b))
+			assert.Equal(t, tcRef.distance, editDistance(tcRef.b, tcRef.a))
+		})
+	}
+}
+
+func Test_Suggest(t *testing.T) {
+	t.Parallel()
+
+	candidates := []string{"country", "count", "city", "County", "language", "a", "b", "c", "d"}
+
+	assert.Equal(t, []string{"County", "count", "country"}, suggest("countr", candidates))
+	assert.Equal(t, []string{"language"}, suggest("langauge", candidates))
+	assert.Equal(t, []string{"a", "b", "c"}, suggest("x", candidates))
+	assert.Empty(t, suggest("population", candidates))
+}
@@ -0,0 +1,131 @@
+package expr
+
+import (
+	"errors"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+)
+
+// TokenKind is the kind of a token for syntax highlighting.
+type TokenKind int
+
+const (
+	// InvalidToken is a character that is no token, like the quote of an
+	// unterminated text, or the "/*" of an unterminated comment.
+	InvalidToken TokenKind = iota
+	// NumberToken is a number, it may be malformed.
+	NumberToken
+	// StringToken is a quoted or raw text, it may contain an invalid escape.
+	StringToken
+	// BoolToken is `true` or `false` in any case.
+	BoolToken
+	// OperatorToken is an operation, with word operators also "and", "or", "not" and "is".
+	OperatorToken
+	// ParenToken is an opening or closing parenthesis.
+	ParenToken
+	// SeparatorToken is the comma between arguments.
+	SeparatorToken
+	// IdentifierToken is the name of a variable or function.
+	IdentifierToken
+	// CommentToken is a `/* ... */` comment.
+	CommentToken
+	// WhitespaceToken is a run of whitespace.
+	WhitespaceToken
+)
+
+var tokenKindNames = [...]string{
+	InvalidToken:    "invalid",
+	NumberToken:     "number",
+	StringToken:     "string",
+	BoolToken:       "bool",
+	OperatorToken:   "operator",
+	ParenToken:      "paren",
+	SeparatorToken:  "separator",
+	IdentifierToken: "identifier",
+	CommentToken:    "comment",
+	WhitespaceToken: "whitespace",
+}
+
+// String returns the lower case name of the kind, e.g. to be used as a CSS class.
+func (k TokenKind) String() string {
+	if k < 0 || int(k) >= len(tokenKindNames) {
+		return "unknown"
+	}
+
+	return tokenKindNames[k]
+}
+
+// Token of the source as tokenized by the parser.
+type Token struct {
+	Kind TokenKind
+	// Value is the text of the source.
+	Value string
+	Span  errs.Span
+}
+
+var tokenKinds = map[tokenType]TokenKind{
+	skipType:                WhitespaceToken,
+	commentType:             CommentToken,
+	contextStartType:        ParenToken,
+	contextEndType:          ParenToken,
+	separatorType:           SeparatorToken,
+	operationType:           OperatorToken,
+	logicalOperationType:    OperatorToken,
+	comparisonOperationType: OperatorToken,
+	numberType:              NumberToken,
+	textType:                StringToken,
+	boolType:                BoolToken,
+	identifierType:          IdentifierToken,
+	invalidType:             InvalidToken,
+}
+
+// Tokenize splits the source into tokens exactly like the parser does, including
+// whitespace and comments, so the tokens cover the whole source. It does not stop
+// on errors, invalid characters are InvalidToken and malformed numbers or texts
+// keep their kind. The located errors of them are returned as errs.List.
+// The options are needed to tokenize word operators.
+func Tokenize(source string, opts ...Option) ([]Token, error) {
+	p := newParser(source, newConfig(opts...))
+	tokens := []Token{}
+
+	for {
+		typ, start, end := p.lexer.scan()
+		if typ == endType {
+			return tokens, p.errors.Err()
+		}
+
+		current := token{Type: typ, Value: source[start:end], span: p.span(start, end)}
+		if typ == identifierType {
+			p.lookupKeyword(&current)
+		}
+
+		tokens = append(tokens, Token{
+			Kind:  tokenKinds[current.Type],
+			Value: source[start:end],
+			Span:  current.span,
+		})
+
+		p.validate(current)
+	}
+}
+
+// validate records the error of an invalid, malformed number or text token.
+// Unlike the parser, errors of adjacent tokens are all recorded, as each token
+// is validated on its own.
+func (p *parser) validate(current token) {
+	var err error
+
+	switch current.Type {
+	case invalidType:
+		err = errs.NewErrorAtSpan(errs.NewErrUnexpectedCharacter(current.Value), current.span)
+	case numberType:
+		_, err = p.number(&current)
+	case textType:
+		_, err = p.text(&current)
+	}
+
+	var located errs.ErrorAtPositionError
+	if errors.As(err, &located) {
+		p.errors = append(p.errors, located)
+	}
+}
@@ -0,0 +1,105 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/goeval/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Tokenize(t *testing.T) {
+	t.Parallel()
+
+	source := "max(a, 0x1F) >= 2 /* limit */ && TRUE"
+	tokens, err := Tokenize(source)
+	require.NoError(t, err)
+
+	expected := []struct {
+		kind  TokenKind
+		value string
+	}{
+		{IdentifierToken, "max"}, {ParenToken, "("}, {IdentifierToken, "a"}, {SeparatorToken, ","},
+		{WhitespaceToken, " "}, {NumberToken, "0x1F"}, {ParenToken, ")"}, {WhitespaceToken, " "},
+		{OperatorToken, ">="}, {WhitespaceToken, " "}, {NumberToken, "2"}, {WhitespaceToken, " "},
+		{CommentToken, "/* limit */"}, {WhitespaceToken, " "}, {OperatorToken, "&&"},
+		{WhitespaceToken, " "}, {BoolToken, "TRUE"},
+	}
+	require.Len(t, tokens, len(expected))
+
+	offset := 0
+	for i, token := range tokens {
+		assert.Equal(t, expected[i].kind, token.Kind, token.Value)
+		assert.Equal(t, expected[i].value, token.Value)
+		assert.Equal(t, errs.NewSpan(source, offset, offset+len(token.Value)), token.Span)
+
+		offset += len(token.Value)
+	}
+}
+
+func Test_Tokenize_Word_Operators(t *testing.T) {
+	t.Parallel()
+
+	tokens, err := Tokenize("a AND not b", WithWordOperators())
+	require.NoError(t, err)
+	assert.Equal(t, OperatorToken, tokens[2].Kind)
+	assert.Equal(t, "AND", tokens[2].Value)
+	assert.Equal(t, OperatorToken, tokens[4].Kind)
+
+	tokens, err = Tokenize("a and b")
+	require.NoError(t, err)
+	assert.Equal(t, IdentifierToken, tokens[2].Kind)
+}
+
+func Test_Tokenize_Errors(t *testing.T) {
+	t.Parallel()
+
+	source := "1__0 + 'a\\q' # \"b"
+	tokens, err := Tokenize(source)
+
+	kinds := []TokenKind{}
+	for _, token := range tokens {
+		if token.Kind != WhitespaceToken {
+			kinds = append(kinds, token.Kind)
+		}
+	}
+
+	assert.Equal(t, []TokenKind{NumberToken, OperatorToken, StringToken, InvalidToken, InvalidToken, IdentifierToken}, kinds)
+	assert.Equal(t, errs.List{
+		errorAt(source, 2, 4, errs.NewErrMalformedNumber("1__0")),
+		errorAt(source, 9, 11, errs.NewErrInvalidEscape(`\q`)),
+		errorAt(source, 13, 14, errs.NewErrUnexpectedCharacter("#")),
+		errorAt(source, 15, 16, errs.NewErrUnexpectedCharacter(`"`)),
+	}, err)
+}
+
+func Test_Tokenize_Adjacent_Errors(t *testing.T) {
+	t.Parallel()
+
+	source := "##1__0'\\q'"
+	tokens, err := Tokenize(source)
+
+	require.Len(t, tokens, 4)
+	assert.Equal(t, errs.List{
+		errorAt(source, 0, 1, errs.NewErrUnexpectedCharacter("#")),
+		errorAt(source, 1, 2, errs.NewErrUnexpectedCharacter("#")),
+		errorAt(source, 4, 6, errs.NewErrMalformedNumber("1__0")),
+		errorAt(source, 7, 9, errs.NewErrInvalidEscape(`\q`)),
+	}, err)
+}
+
+func Test_Tokenize_Empty(t *testing.T) {
+	t.Parallel()
+
+	tokens, err := Tokenize("")
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func Test_TokenKind_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "comment", CommentToken.String())
+	assert.Equal(t, "invalid", InvalidToken.String())
+	assert.Equal(t, "unknown", TokenKind(-1).String())
+}
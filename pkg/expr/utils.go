@@ -1,10 +1,20 @@
 package expr
 
-import "strings"
+import (
+	"math"
+	"math/big"
+	"strings"
+)
 
 func convertFloat(value interface{}) float64 {
 	if v, ok := value.(float64); ok {
 		return v
+	} else if v, ok := value.(int64); ok {
+		return float64(v)
+	} else if v, ok := value.(*big.Int); ok {
+		f, _ := new(big.Float).SetInt(v).Float64()
+
+		return f
 	} else if v, ok := value.(string); ok {
 		return float64(len(v))
 	} else if v, ok := value.(bool); ok && v {
@@ -17,6 +27,10 @@ func convertFloat(value interface{}) float64 {
 func convertBool(value interface{}) bool {
 	if v, ok := value.(float64); ok {
 		return v > 0
+	} else if v, ok := value.(int64); ok {
+		return v > 0
+	} else if v, ok := value.(*big.Int); ok {
+		return v.Sign() > 0
 	} else if v, ok := value.(string); ok {
 		return strings.ToLower(v) == "true"
 	} else if v, ok := value.(bool); ok && v {
@@ -25,3 +39,65 @@ func convertBool(value interface{}) bool {
 
 	return false
 }
+
+func convertBigInt(value interface{}) *big.Int {
+	if v, ok := value.(*big.Int); ok {
+		return v
+	} else if v, ok := value.(int64); ok {
+		return big.NewInt(v)
+	}
+
+	return big.NewInt(int64(convertFloat(value)))
+}
+
+func isInteger(value interface{}) bool {
+	switch value.(type) {
+	case int64, *big.Int:
+		return true
+	}
+
+	return false
+}
+
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case float64, int64, *big.Int:
+		return true
+	}
+
+	return false
+}
+
+func isNaN(value interface{}) bool {
+	v, ok := value.(float64)
+
+	return ok && math.IsNaN(v)
+}
+
+// equal compares numbers by value and everything else by identity.
+func equal(left, right interface{}) bool {
+	if isNumber(left) && isNumber(right) {
+		return compare(left, right) == 0
+	}
+
+	return left == right
+}
+
+// compare returns -1, 0 or 1 like cmp.Compare.
+// Integers are compared exactly, everything else as float64.
+func compare(left, right interface{}) int {
+	if isInteger(left) && isInteger(right) {
+		return convertBigInt(left).Cmp(convertBigInt(right))
+	}
+
+	leftValue := convertFloat(left)
+	rightValue := convertFloat(right)
+
+	if leftValue < rightValue {
+		return -1
+	} else if leftValue > rightValue {
+		return 1
+	}
+
+	return 0
+}
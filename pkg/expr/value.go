@@ -0,0 +1,145 @@
+package expr
+
+import (
+	"math"
+	"math/big"
+)
+
+// valueKind is the type of a tagged value.
+type valueKind uint8
+
+const (
+	// otherKind are nil and values that are only passed through to functions.
+	otherKind valueKind = iota
+	floatKind
+	intKind
+	boolKind
+	stringKind
+	bigIntKind
+)
+
+// tagged is a value during evaluation, tagged by its kind. Numbers and bools
+// are stored unboxed in bits, so operations on them do not allocate. Strings,
+// *big.Int and other values are kept boxed in ref as they are already boxed
+// in environments and results of functions, converting them does not allocate.
+// Values are converted to interface{} only for functions and the Result.
+type tagged struct {
+	kind valueKind
+	// bits of the int64, float64 or bool.
+	bits uint64
+	ref  interface{}
+}
+
+func floatValue(value float64) tagged {
+	return tagged{kind: floatKind, bits: math.Float64bits(value)}
+}
+
+func intValue(value int64) tagged {
+	return tagged{kind: intKind, bits: uint64(value)}
+}
+
+func boolValue(value bool) tagged {
+	if value {
+		return tagged{kind: boolKind, bits: 1}
+	}
+
+	return tagged{kind: boolKind}
+}
+
+// valueOf tags a value that is already normalized.
+func valueOf(value interface{}) tagged {
+	switch v := value.(type) {
+	case float64:
+		return floatValue(v)
+	case int64:
+		return intValue(v)
+	case bool:
+		return boolValue(v)
+	case string:
+		return tagged{kind: stringKind, ref: value}
+	case *big.Int:
+		return tagged{kind: bigIntKind, ref: value}
+	}
+
+	return tagged{kind: otherKind, ref: value}
+}
+
+// normalizeValue is like normalize, but does not box the common numbers.
+func normalizeValue(cfg *config, value interface{}) tagged {
+	switch v := value.(type) {
+	case float64:
+		return floatValue(v)
+	case int:
+		if !cfg.integers {
+			return floatValue(float64(v))
+		}
+
+		return intValue(int64(v))
+	case int64:
+		if !cfg.integers {
+			return floatValue(float64(v))
+		}
+
+		return intValue(v)
+	case bool:
+		return boolValue(v)
+	case string:
+		return tagged{kind: stringKind, ref: value}
+	}
+
+	return valueOf(normalize(cfg, value))
+}
+
+// boxed returns the value as used by functions and results, numbers are boxed.
+func (v tagged) boxed() interface{} {
+	switch v.kind {
+	case floatKind:
+		return v.float64()
+	case intKind:
+		return v.int64()
+	case boolKind:
+		return v.bits != 0
+	}
+
+	return v.ref
+}
+
+func (v tagged) isNumber() bool {
+	return v.kind == floatKind || v.kind == intKind
+}
+
+func (v tagged) float64() float64 {
+	return math.Float64frombits(v.bits)
+}
+
+func (v tagged) int64() int64 {
+	return int64(v.bits)
+}
+
+// float converts the value like convertFloat.
+func (v tagged) float() float64 {
+	switch v.kind {
+	case floatKind:
+		return v.float64()
+	case intKind:
+		return float64(v.int64())
+	case boolKind:
+		return float64(v.bits)
+	}
+
+	return convertFloat(v.ref)
+}
+
+// bool converts the value like convertBool.
+func (v tagged) bool() bool {
+	switch v.kind {
+	case floatKind:
+		return v.float64() > 0
+	case intKind:
+		return v.int64() > 0
+	case boolKind:
+		return v.bits != 0
+	}
+
+	return convertBool(v.ref)
+}
@@ -0,0 +1,154 @@
+package expr
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Tagged(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name  string
+		value interface{}
+		kind  valueKind
+	}{
+		{name: "Float64", value: 2.5, kind: floatKind},
+		{name: "Float64_Negative", value: -1.0, kind: floatKind},
+		{name: "Float64_Inf", value: math.Inf(1), kind: floatKind},
+		{name: "Int64", value: int64(-3), kind: intKind},
+		{name: "Int64_Max", value: int64(math.MaxInt64), kind: intKind},
+		{name: "Bool_True", value: true, kind: boolKind},
+		{name: "Bool_False", value: false, kind: boolKind},
+		{name: "String", value: "hello", kind: stringKind},
+		{name: "String_True", value: "TRUE", kind: stringKind},
+		{name: "BigInt", value: new(big.Int).Lsh(big.NewInt(1), 70), kind: bigIntKind},
+		{name: "Nil", value: nil, kind: otherKind},
+		{name: "Other", value: []int{1}, kind: otherKind},
+	}
+
+	for _, tc := range tcs {
+		tcRef := tc
+
+		t.Run(tcRef.name, func(t *testing.T) {
+			t.Parallel()
+
+			value := valueOf(tcRef.value)
+			assert.Equal(t, tcRef.kind, value.kind)
+			assert.Equal(t, tcRef.value, value.boxed())
+			assert.Equal(t, convertFloat(tcRef.value), value.float())
+			assert.Equal(t, convertBool(tcRef.value), value.bool())
+		})
+	}
+}
+
+func Test_NormalizeValue(t *testing.T) {
+	t.Parallel()
+
+	values := []interface{}{
+		1.5, 2, int8(-3), int64(4), uint(5), uint64(math.MaxUint64), float32(0.5),
+		big.NewInt(7), "text", true, nil, struct{}{},
+	}
+
+	for _, integers := range []bool{false, true} {
+		cfg := newConfig()
+		cfg.integers = integers
+
+		for _, value := range values {
+			assert.Equal(t, valueOf(normalize(cfg, value)), normalizeValue(cfg, value), "%T with integers %v", value, integers)
+		}
+	}
+}
+
+// requireNoAllocs fails unless the program evaluates without allocations,
+// the value of numbers is only boxed for the Result.
+func requireNoAllocs(tb testing.TB, program *Program, env Env) {
+	tb.Helper()
+
+	e := &evaluation{ctx: context.Background(), config: program.config}
+
+	_, err := program.value(e, env)
+	require.NoError(tb, err)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = program.value(e, env)
+	})
+	require.Zero(tb, allocs, program.Source())
+}
+
+// Test_Program_Value_Allocations is not parallel, as allocations are counted globally.
+func Test_Program_Value_Allocations(t *testing.T) {
+	env := Env{"x": 3, "y": 2.5, "n": int64(7), "flag": true, "name": "abc"}
+
+	tcs := []struct {
+		name       string
+		expression string
+		boolean    bool
+		opts       []Option
+	}{
+		{name: "Arithmetic", expression: "(x + 1) * (y - 2) / 3 - 2 ** x"},
+		{name: "Division", expression: "x % 2 + x // 2 + (x & 1 | 4) + (n << 2)"},
+		{name: "Integers", expression: "n * 3 - x // 2 + n % 4 + n / 2", opts: []Option{WithIntegers(OverflowError)}},
+		{name: "Mixed_Integers", expression: "n * 1.5 > y", boolean: true, opts: []Option{WithIntegers(OverflowPromote)}},
+		{name: "Comparison", expression: "x + 1 > y && y <= 2.5 || x != 3", boolean: true},
+		{name: "Logical", expression: "!flag || x > 2 && (y < 3 or false)", boolean: true, opts: []Option{WithWordOperators()}},
+		{name: "String_Comparison", expression: "name == 'abc' && name != 'x'", boolean: true},
+		{name: "Limits", expression: "x * y > 1", boolean: true, opts: []Option{WithMaxSteps(10), WithMaxStringLength(3)}},
+		{name: "Optimized", expression: "x * (2 + 3) > 4 * 2", boolean: true, opts: []Option{WithOptimize()}},
+	}
+
+	for _, backend := range backends {
+		for _, tc := range tcs {
+			tcRef := tc
+
+			t.Run(backend.name+"_"+tcRef.name, func(t *testing.T) {
+				program, err := Compile(tcRef.expression, append(backend.opts, tcRef.opts...)...)
+				require.NoError(t, err)
+
+				requireNoAllocs(t, program, env)
+
+				if tcRef.boolean {
+					// bools are boxed without allocation
+					assert.Zero(t, testing.AllocsPerRun(100, func() { program.Eval(env) }))
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkProgram_Value_Numeric(b *testing.B) {
+	benchmarkValue(b, "(x + 1) * (y - 2) / 3 - x % 2")
+}
+
+func BenchmarkProgram_Value_Boolean(b *testing.B) {
+	benchmarkValue(b, "x + 1 > y && y <= 2.5 || !flag")
+}
+
+func benchmarkValue(b *testing.B, expression string) {
+	b.Helper()
+
+	env := Env{"x": 3, "y": 2.5, "flag": true}
+
+	for _, backend := range backends {
+		b.Run(backend.name, func(b *testing.B) {
+			program, err := Compile(expression, backend.opts...)
+			require.NoError(b, err)
+
+			requireNoAllocs(b, program, env)
+
+			e := &evaluation{ctx: context.Background(), config: program.config}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_, _ = program.value(e, env)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package expr
+
+// stackSize of the stack the virtual machine allocates on the Go stack,
+// bigger programs allocate their stack on the heap.
+const stackSize = 16
+
+// run executes the bytecode and returns the value left on the stack.
+func (e *evaluation) run(b *bytecode) (tagged, error) {
+	var local [stackSize]tagged
+
+	stack := local[:0]
+	if b.stackSize > stackSize {
+		stack = make([]tagged, 0, b.stackSize)
+	}
+
+	// steps and the context only need to be checked if they can stop the evaluation
+	checked := e.config.maxSteps > 0 || e.ctx.Done() != nil
+
+	for pc := 0; pc < len(b.code); pc++ {
+		var (
+			instruction = b.code[pc]
+			value       tagged
+			err         error
+		)
+
+		switch instruction.op {
+		case opEnter:
+			if checked {
+				if err := e.enter(b.nodes[pc]); err != nil {
+					return tagged{}, err
+				}
+			}
+
+			continue
+		case opConstant:
+			stack = append(stack, b.constants[instruction.operand])
+
+			continue
+		case opVariable:
+			if value, err = e.variable(b.nodes[pc]); err != nil {
+				return tagged{}, err
+			}
+
+			stack = append(stack, value)
+
+			continue
+		case opCall:
+			args := make([]interface{}, instruction.operand)
+			for i, arg := range stack[len(stack)-instruction.operand:] {
+				args[i] = arg.boxed()
+			}
+
+			stack = stack[:len(stack)-instruction.operand]
+
+			if value, err = e.invoke(b.nodes[pc], args); err != nil {
+				return tagged{}, err
+			}
+
+			stack = append(stack, value)
+
+			continue
+		case opNot:
+			stack[len(stack)-1] = boolValue(!stack[len(stack)-1].bool())
+
+			continue
+		case opBool:
+			stack[len(stack)-1] = boolValue(stack[len(stack)-1].bool())
+
+			continue
+		case opJumpIfFalse, opJumpIfTrue:
+			short := instruction.op == opJumpIfTrue
+			if stack[len(stack)-1].bool() == short {
+				stack[len(stack)-1] = boolValue(short)
+				pc = instruction.operand - 1
+			} else {
+				stack = stack[:len(stack)-1]
+			}
+
+			continue
+		}
+
+		left, right := stack[len(stack)-2], stack[len(stack)-1]
+		if instruction.op >= opFloatAdd {
+			value, err = e.floats(b.nodes[pc], instruction.op-floatOffset, left.float64(), right.float64())
+		} else {
+			value, err = e.binary(b.nodes[pc], instruction.op, left, right)
+		}
+
+		if err != nil {
+			return tagged{}, err
+		}
+
+		stack = stack[:len(stack)-1]
+		stack[len(stack)-1] = value
+	}
+
+	return stack[0], nil
+}